@@ -0,0 +1,10 @@
+data "p0_access_policies" "all" {}
+
+# Lists an `import` block for each policy, to paste into a configuration and
+# generate with `terraform plan -generate-config-out=policies.tf`.
+output "import_blocks" {
+  value = join("\n", [
+    for policy in data.p0_access_policies.all.policies :
+    "import {\n  to = p0_access_policy.${replace(policy.name, "/[^A-Za-z0-9_-]/", "_")}\n  id = \"${coalesce(policy.id, policy.name)}\"\n}"
+  ])
+}
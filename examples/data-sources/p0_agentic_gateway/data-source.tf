@@ -0,0 +1,7 @@
+data "p0_agentic_gateway" "primary" {
+  id = "primary"
+}
+
+output "gateway_oauth_endpoint" {
+  value = data.p0_agentic_gateway.primary.oauth_endpoint
+}
@@ -0,0 +1,8 @@
+# All MCP servers hosted by the "primary" gateway.
+data "p0_agentic_servers" "primary" {
+  gateway = "primary"
+}
+
+output "server_ids" {
+  value = [for server in data.p0_agentic_servers.primary.servers : server.id]
+}
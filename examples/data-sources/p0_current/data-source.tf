@@ -0,0 +1,21 @@
+data "p0_current" "this" {}
+
+# Refuse to apply this configuration to any organization other than production.
+resource "terraform_data" "org_guard" {
+  lifecycle {
+    precondition {
+      condition     = data.p0_current.this.org == "my-prod-org"
+      error_message = "This configuration must only be applied to my-prod-org; the provider is configured for ${data.p0_current.this.org}."
+    }
+  }
+}
+
+# Only owners may apply this configuration.
+resource "terraform_data" "owner_guard" {
+  lifecycle {
+    precondition {
+      condition     = data.p0_current.this.roles != null ? contains(data.p0_current.this.roles, "owner") : false
+      error_message = "${coalesce(data.p0_current.this.identity, "The caller")} is not an owner of ${data.p0_current.this.org}."
+    }
+  }
+}
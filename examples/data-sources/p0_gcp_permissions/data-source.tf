@@ -0,0 +1,40 @@
+# Reads the permissions P0 requires without managing the p0_gcp installation, e.g.
+# from an organization-bootstrap module that owns every custom role. Until the
+# organization-level p0_gcp installation exists, `installed` is false and the
+# roles are not created.
+data "p0_gcp_permissions" "current" {}
+
+locals {
+  organization_id = "123456789012"
+  p0_roles        = data.p0_gcp_permissions.current.installed ? 1 : 0
+}
+
+resource "google_organization_iam_custom_role" "org_wide_policy" {
+  count       = local.p0_roles
+  org_id      = local.organization_id
+  role_id     = data.p0_gcp_permissions.current.org_wide_policy.custom_role.id
+  title       = data.p0_gcp_permissions.current.org_wide_policy.custom_role.name
+  description = "Role for the P0 org-wide policy-read installation"
+  permissions = data.p0_gcp_permissions.current.org_wide_policy.permissions
+}
+
+resource "google_organization_iam_custom_role" "access_logs" {
+  count       = local.p0_roles
+  org_id      = local.organization_id
+  role_id     = data.p0_gcp_permissions.current.access_logs.custom_role.id
+  title       = data.p0_gcp_permissions.current.access_logs.custom_role.name
+  description = "Role for the P0 access-logs installation"
+  permissions = data.p0_gcp_permissions.current.access_logs.permissions
+}
+
+# Organization-level IAM assessment requires both permission sets.
+resource "google_organization_iam_custom_role" "iam_assessment" {
+  count       = local.p0_roles
+  org_id      = local.organization_id
+  role_id     = "p0IamAssessment"
+  title       = "P0 IAM assessment"
+  permissions = concat(
+    data.p0_gcp_permissions.current.iam_assessment.permissions.project,
+    data.p0_gcp_permissions.current.iam_assessment.permissions.organization,
+  )
+}
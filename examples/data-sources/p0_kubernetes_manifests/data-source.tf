@@ -0,0 +1,60 @@
+resource "p0_kubernetes_staged" "example" {
+  id                    = "my-eks-cluster"
+  cluster_arn           = data.aws_eks_cluster.example.arn
+  cluster_endpoint      = data.aws_eks_cluster.example.endpoint
+  certificate_authority = data.aws_eks_cluster.example.certificate_authority[0].data
+}
+
+variable "p0_admission_controller_version" {
+  type        = string
+  description = "Release tag of the P0 admission controller image"
+}
+
+variable "p0_braekhus_version" {
+  type        = string
+  description = "Release tag of the braekhus proxy image"
+}
+
+# These are copied from the Kubernetes install instructions in the P0 app.
+variable "p0_admission_controller" {
+  type = object({
+    port     = number
+    tls_path = string
+    webhook = object({
+      path = string
+      rules = list(object({
+        api_groups   = list(string)
+        api_versions = list(string)
+        operations   = list(string)
+        resources    = list(string)
+      }))
+    })
+  })
+  description = "The admission controller's port, TLS path, and webhook path and rules"
+}
+
+variable "p0_braekhus_args" {
+  type        = list(string)
+  description = "The braekhus proxy's arguments"
+}
+
+# Renders the in-cluster components from the staged PKI values. See the
+# p0_kubernetes example for the full install chain.
+data "p0_kubernetes_manifests" "example" {
+  id                            = p0_kubernetes_staged.example.id
+  admission_controller_image    = "p0security/p0-admission-controller:${var.p0_admission_controller_version}"
+  admission_controller_port     = var.p0_admission_controller.port
+  admission_controller_tls_path = var.p0_admission_controller.tls_path
+  webhook_path                  = var.p0_admission_controller.webhook.path
+  webhook_rules                 = var.p0_admission_controller.webhook.rules
+  braekhus_image                = "p0security/braekhus:${var.p0_braekhus_version}"
+  braekhus_args                 = var.p0_braekhus_args
+}
+
+# The manifests embed the admission controller's private key, so they are
+# sensitive; only their count is revealed.
+resource "kubernetes_manifest" "p0" {
+  count = nonsensitive(length(data.p0_kubernetes_manifests.example.manifests))
+
+  manifest = jsondecode(data.p0_kubernetes_manifests.example.manifests[count.index])
+}
@@ -0,0 +1,15 @@
+data "p0_settings" "current" {}
+
+# Fail the plan if the organization allows access for longer than one week.
+resource "terraform_data" "max_access_guard" {
+  lifecycle {
+    precondition {
+      condition     = data.p0_settings.current.max_access == null || contains(["s", "m", "h", "d"], data.p0_settings.current.max_access.unit) || data.p0_settings.current.max_access.time <= 1
+      error_message = "The organization's maximum access duration must not exceed one week."
+    }
+  }
+}
+
+output "expiry_options" {
+  value = [for option in data.p0_settings.current.expiry_options : "${option.time}${option.unit}"]
+}
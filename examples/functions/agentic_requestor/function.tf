@@ -0,0 +1,13 @@
+resource "p0_access_policy" "example" {
+  name = "alice-agents-headless"
+  # Only attributes relevant to each `type` need to be set.
+  requestor = provider::p0::agentic_requestor(
+    { type = "agent-owner", owner = "alice@example.com" },
+    { type = "none" },
+  )
+  resource = {
+    type    = "integration"
+    service = "aws"
+  }
+  approval = [{ type = "p0" }]
+}
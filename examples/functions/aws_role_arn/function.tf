@@ -0,0 +1,13 @@
+resource "p0_aws_iam_write_staged" "example" {
+  id        = "123456789012"
+  partition = "aws-us-gov"
+}
+
+# "arn:aws-us-gov:iam::123456789012:role/<role name>"
+output "p0_role_arn" {
+  value = provider::p0::aws_role_arn(
+    p0_aws_iam_write_staged.example.partition,
+    p0_aws_iam_write_staged.example.id,
+    p0_aws_iam_write_staged.example.role.name,
+  )
+}
@@ -0,0 +1,5 @@
+resource "p0_access_durations" "example" {
+  approvable      = provider::p0::duration("36h") # { time = 36, unit = "h" }
+  max_access      = provider::p0::duration("14d") # { time = 2, unit = "w" }
+  standing_access = provider::p0::duration("1d12h")
+}
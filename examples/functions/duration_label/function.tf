@@ -0,0 +1,12 @@
+locals {
+  expiry_options = [for d in ["1h", "8h", "1d", "7d"] : provider::p0::duration(d)]
+}
+
+resource "p0_expiry_options" "example" {
+  options = local.expiry_options
+}
+
+# ["1 hour", "8 hours", "1 day", "1 week"]
+output "expiry_option_labels" {
+  value = [for option in local.expiry_options : provider::p0::duration_label(option)]
+}
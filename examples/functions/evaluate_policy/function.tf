@@ -0,0 +1,30 @@
+resource "p0_access_policy" "eng_prod" {
+  name = "eng-prod"
+  requestor = provider::p0::user_requestor("alice@example.com")
+  resource = {
+    type        = "integration"
+    service     = "aws"
+    access_type = "role"
+    filters = {
+      account = {
+        effect  = "keep"
+        pattern = "^prod-"
+      }
+    }
+  }
+  approval = [
+    { type = "p0" },
+  ]
+}
+
+check "alice_can_request_prod" {
+  assert {
+    condition = provider::p0::evaluate_policy(p0_access_policy.eng_prod, {
+      email       = "alice@example.com"
+      service     = "aws"
+      access_type = "role"
+      resource    = { account = "prod-123" }
+    }).matches
+    error_message = "eng-prod should cover Alice's requests for production accounts"
+  }
+}
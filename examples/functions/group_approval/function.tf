@@ -0,0 +1,23 @@
+locals {
+  security = {
+    directory = "okta"
+    id        = "00g1abcd2EFGH3ijk4l6"
+    label     = "Security"
+  }
+}
+
+resource "p0_access_policy" "example" {
+  name = "any-aws"
+  requestor = {
+    type = "any"
+  }
+  resource = {
+    type    = "integration"
+    service = "aws"
+  }
+  approval = [
+    merge(provider::p0::group_approval([local.security], "keep"), {
+      options = { require_reason = true }
+    }),
+  ]
+}
@@ -0,0 +1,17 @@
+locals {
+  engineering = {
+    directory = "okta"
+    id        = "00g1abcd2EFGH3ijk4l5"
+    label     = "Engineering"
+  }
+}
+
+resource "p0_access_policy" "example" {
+  name      = "engineering-aws"
+  requestor = provider::p0::group_requestor([local.engineering], "keep")
+  resource = {
+    type    = "integration"
+    service = "aws"
+  }
+  approval = [{ type = "p0" }]
+}
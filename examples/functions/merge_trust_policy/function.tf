@@ -0,0 +1,17 @@
+resource "p0_aws_iam_write_staged" "example" {
+  id = "123456789012"
+}
+
+# Also allow a break-glass role in the same account to assume P0's role.
+resource "aws_iam_role" "p0_role" {
+  name = p0_aws_iam_write_staged.example.role.name
+  assume_role_policy = provider::p0::merge_trust_policy(
+    p0_aws_iam_write_staged.example.role.trust_policy,
+    jsonencode({
+      Sid       = "BreakGlass"
+      Effect    = "Allow"
+      Principal = { AWS = "arn:aws:iam::123456789012:role/BreakGlass" }
+      Action    = "sts:AssumeRole"
+    }),
+  )
+}
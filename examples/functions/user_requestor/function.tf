@@ -0,0 +1,9 @@
+resource "p0_access_policy" "example" {
+  name      = "alice-aws"
+  requestor = provider::p0::user_requestor("alice@example.com")
+  resource = {
+    type    = "integration"
+    service = "aws"
+  }
+  approval = [{ type = "p0" }]
+}
@@ -0,0 +1,28 @@
+resource "p0_access_policies" "all" {
+  # Report, rather than delete, policies created outside of Terraform
+  mode = "report"
+
+  policies = {
+    "any-aws" = {
+      requestor = {
+        type = "any"
+      }
+      resource = {
+        type    = "integration"
+        service = "aws"
+      }
+      approval = [
+        { type = "p0" },
+      ]
+    }
+    "alice-anything" = {
+      requestor = provider::p0::user_requestor("alice@example.com")
+      resource = {
+        type = "any"
+      }
+      approval = [
+        { type = "persistent" },
+      ]
+    }
+  }
+}
@@ -0,0 +1,7 @@
+# Access policies can be imported by name or by id. Run
+# `terraform plan -generate-config-out=generated.tf` to also generate their
+# configuration.
+import {
+  to = p0_access_policy.example
+  id = "okta-aws-developers-oncall"
+}
@@ -0,0 +1,2 @@
+# Access policies can be imported by name or by id.
+terraform import p0_access_policy.example okta-aws-developers-oncall
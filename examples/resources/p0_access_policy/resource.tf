@@ -96,3 +96,206 @@ resource "p0_access_policy" "agentic_federated" {
     }
   }]
 }
+
+# Per-rule request constraints: production database access may be requested
+# for at most four hours, and only with a ticket number as the reason.
+resource "p0_access_policy" "prod_database" {
+  name = "prod-database-oncall"
+  requestor = {
+    type = "any"
+  }
+  resource = {
+    type    = "integration"
+    service = "postgres"
+  }
+  approval = [{
+    type        = "auto"
+    integration = "pagerduty"
+    options = {
+      require_reason = true
+      max_duration   = provider::p0::duration("4h")
+      reason_pattern = "^[A-Z]+-[0-9]+"
+    }
+  }]
+}
+
+# Schedules: auto-approve during business hours, and require approval from a
+# directory group at other times.
+resource "p0_access_policy" "business_hours" {
+  name = "aws-business-hours"
+  requestor = {
+    type = "any"
+  }
+  resource = {
+    type    = "integration"
+    service = "aws"
+  }
+  approval = [{
+    type        = "auto"
+    integration = "pagerduty"
+  }]
+  schedule = {
+    timezone    = "America/New_York"
+    days        = ["monday", "tuesday", "wednesday", "thursday", "friday"]
+    time_ranges = [{ start = "09:00", end = "18:00" }]
+  }
+}
+
+resource "p0_access_policy" "off_hours" {
+  name = "aws-off-hours"
+  requestor = {
+    type = "any"
+  }
+  resource = {
+    type    = "integration"
+    service = "aws"
+  }
+  approval = [{
+    type   = "group"
+    effect = "keep"
+    groups = [{
+      directory = "okta"
+      id        = "00abcdefghijklmno697"
+      label     = "AWS Developers"
+    }]
+  }]
+}
+
+# Multi-stage approval: the requestor's manager approves first, then two
+# distinct security reviewers.
+resource "p0_access_policy" "prod_admin" {
+  name = "aws-prod-admin"
+  requestor = {
+    type = "any"
+  }
+  resource = {
+    type        = "integration"
+    service     = "aws"
+    access_type = "role"
+  }
+  approval = [
+    {
+      type             = "requestor-profile"
+      directory        = "okta"
+      profile_property = "manager"
+      stage            = 1
+    },
+    {
+      type  = "p0"
+      stage = 2
+      options = {
+        min_approvers = 2
+      }
+    },
+  ]
+}
+
+# Break-glass route: on-call engineers may request emergency production access,
+# which any security reviewer may grant. Grants are announced in Slack, last at
+# most an hour, and must be reviewed within a day.
+resource "p0_access_policy" "break_glass" {
+  name = "aws-prod-break-glass"
+  requestor = {
+    type   = "group"
+    effect = "keep"
+    groups = [{
+      directory = "okta"
+      id        = "00abcdefghijklmno697"
+      label     = "AWS Developers"
+    }]
+  }
+  resource = {
+    type    = "integration"
+    service = "aws"
+  }
+  approval = [{
+    type = "p0"
+    options = {
+      break_glass_approver = true
+    }
+  }]
+  break_glass = {
+    max_duration = provider::p0::duration("1h")
+    notifications = [{
+      integration = "slack"
+      target      = "C0123456789"
+    }]
+    require_review = true
+    review_within  = provider::p0::duration("1d")
+  }
+}
+
+# Members of a principal set may request access, which any other member may
+# approve. Changing the set's groups updates every policy that references it.
+resource "p0_access_policy" "sre_principal_set" {
+  name = "sre-gcloud"
+  requestor = {
+    type          = "principal-set"
+    principal_set = p0_principal_set.sre.name
+  }
+  resource = {
+    type    = "integration"
+    service = "gcloud"
+  }
+  approval = [{
+    type          = "principal-set"
+    principal_set = p0_principal_set.sre.name
+  }]
+}
+
+# Profile conditions: full-time engineers and SREs, per their Okta profile,
+# may request read access to production without further approval.
+resource "p0_access_policy" "engineering_profile" {
+  name = "engineering-full-time-aws-read"
+  requestor = {
+    type   = "profile"
+    effect = "keep"
+    profile = {
+      directory = "okta"
+      attributes = {
+        department   = ["Engineering", "SRE"]
+        employeeType = ["Full-time"]
+      }
+    }
+  }
+  resource = {
+    type        = "integration"
+    service     = "aws"
+    access_type = "permission-set"
+  }
+  approval = [{
+    type = "persistent"
+  }]
+}
+
+# Approval requests for this policy are also posted to the team's Slack channel
+# and page its on-call PagerDuty service. Both integrations must be installed.
+resource "p0_access_policy" "payments_notifications" {
+  name = "payments-aws"
+  requestor = {
+    type   = "group"
+    effect = "keep"
+    groups = [{
+      directory = "okta"
+      id        = "00g1234567890abcdef"
+      label     = "Payments Engineering"
+    }]
+  }
+  resource = {
+    type    = "integration"
+    service = "aws"
+  }
+  approval = [{
+    type = "p0"
+  }]
+  notifications = [
+    {
+      integration = "slack"
+      target      = "C0987654321"
+    },
+    {
+      integration = "pagerduty"
+      target      = "PABC123"
+    },
+  ]
+}
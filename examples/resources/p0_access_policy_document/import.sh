@@ -0,0 +1,3 @@
+# Access policy documents can be imported by policy name or id. The imported
+# policy's document is stored, as JSON, in `content`.
+terraform import 'p0_access_policy_document.policies["engineering.yaml"]' engineering-aws
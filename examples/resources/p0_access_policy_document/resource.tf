@@ -0,0 +1,5 @@
+# Manages one access policy per YAML file in the policies directory.
+resource "p0_access_policy_document" "policies" {
+  for_each = fileset("${path.module}/policies", "*.yaml")
+  content  = file("${path.module}/policies/${each.value}")
+}
@@ -0,0 +1,2 @@
+# Principal sets can be imported by name.
+terraform import p0_principal_set.sre sre
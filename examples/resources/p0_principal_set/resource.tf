@@ -0,0 +1,23 @@
+resource "p0_principal_set" "sre" {
+  name        = "sre"
+  description = "Site reliability engineers"
+  groups = [
+    {
+      directory = "okta"
+      id        = "00abcdefghijklmno697"
+      label     = "SRE"
+    },
+    {
+      directory = "okta"
+      id        = "00abcdefghijklmno698"
+      label     = "SRE Contractors"
+    },
+  ]
+  users = ["alice@example.com"]
+
+  # Lets policies that reference the set switch to its replacement before the
+  # prior set is deleted, if the set is renamed.
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,274 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// PatternSyntaxDescription documents the pattern dialect accepted by
+// PatternValidator, for use in attribute descriptions.
+const PatternSyntaxDescription = `Patterns are unanchored regular expressions, written in the syntax shared by RE2 and JavaScript (so lookaround, backreferences, and inline flags other than ` + "`(?i)`" + ` are not supported).`
+
+// inlineFlagsRegex matches the start of an RE2 inline flag group, e.g. "(?i)" or
+// "(?s:", capturing its flags.
+var inlineFlagsRegex = regexp.MustCompile(`^\(\?([imsU-]+)[:)]`)
+
+// matchAllProbes are sample strings that a pattern matching every string will
+// match; used to detect patterns like ".*" that do not narrow anything.
+var matchAllProbes = []string{"", "\n", "p0", "arn:aws:iam::123456789012:role/Example"}
+
+// dialectError returns why pattern uses syntax that RE2 and the P0 backend's
+// JavaScript regular expressions interpret differently (or that only one
+// supports), or "" if it uses neither.
+func dialectError(pattern string) string {
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			switch pattern[i] {
+			case 'A', 'z':
+				return "`\\A` and `\\z` are not supported; use `^` and `$` instead"
+			case 'Q', 'E':
+				return "`\\Q...\\E` quoting is not supported; escape each special character instead"
+			case 'C':
+				return "`\\C` is not supported"
+			case 'p', 'P':
+				return "Unicode character classes (`\\p`) are not supported"
+			}
+		case inClass:
+			if c == ']' {
+				inClass = false
+			} else if strings.HasPrefix(pattern[i:], "[:") {
+				return "POSIX character classes (e.g. `[[:alpha:]]`) are not supported; use an explicit range (e.g. `[A-Za-z]`) instead"
+			}
+		case c == '[':
+			inClass = true
+			rest := strings.TrimPrefix(pattern[i+1:], "^")
+			if strings.HasPrefix(rest, "]") {
+				return "a `]` at the start of a character class must be escaped as `\\]`"
+			}
+		case c == '(' && strings.HasPrefix(pattern[i:], "(?P<"):
+			return "named groups must be written as `(?<name>...)`, not `(?P<name>...)`"
+		case c == '(' && inlineFlagsRegex.MatchString(pattern[i:]):
+			if inlineFlagsRegex.FindStringSubmatch(pattern[i:])[1] != "i" {
+				return "only the case-insensitive inline flag (`(?i)`) is supported"
+			}
+		}
+	}
+	return ""
+}
+
+// isUnboundedRepeat reports whether re repeats its sub-expression without limit.
+func isUnboundedRepeat(re *syntax.Regexp) bool {
+	return re.Op == syntax.OpStar || re.Op == syntax.OpPlus || (re.Op == syntax.OpRepeat && re.Max == -1)
+}
+
+// firstUnboundedRepeat returns the first unbounded repetition in re, or nil.
+func firstUnboundedRepeat(re *syntax.Regexp) *syntax.Regexp {
+	if isUnboundedRepeat(re) {
+		return re
+	}
+	for _, sub := range re.Sub {
+		if repeat := firstUnboundedRepeat(sub); repeat != nil {
+			return repeat
+		}
+	}
+	return nil
+}
+
+// sequence flattens the groups and concatenations of re into the sequence of
+// elements it matches one after another.
+func sequence(re *syntax.Regexp) []*syntax.Regexp {
+	switch re.Op {
+	case syntax.OpCapture:
+		return sequence(re.Sub[0])
+	case syntax.OpConcat:
+		var elements []*syntax.Regexp
+		for _, sub := range re.Sub {
+			elements = append(elements, sequence(sub)...)
+		}
+		return elements
+	}
+	return []*syntax.Regexp{re}
+}
+
+// literalSet returns the character ranges matching the literal r, including
+// its other cases if flags make it case-insensitive.
+func literalSet(r rune, flags syntax.Flags) []rune {
+	set := []rune{r, r}
+	if flags&syntax.FoldCase != 0 {
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			set = append(set, f, f)
+		}
+	}
+	return set
+}
+
+// charSet returns the character ranges (as pairs of bounds, like
+// syntax.Regexp.Rune) of re if it matches exactly one character.
+func charSet(re *syntax.Regexp) ([]rune, bool) {
+	switch re.Op {
+	case syntax.OpCapture:
+		return charSet(re.Sub[0])
+	case syntax.OpLiteral:
+		if len(re.Rune) == 1 {
+			return literalSet(re.Rune[0], re.Flags), true
+		}
+	case syntax.OpCharClass:
+		return re.Rune, true
+	case syntax.OpAnyCharNotNL:
+		return []rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune}, true
+	case syntax.OpAnyChar:
+		return []rune{0, unicode.MaxRune}, true
+	}
+	return nil, false
+}
+
+// firstChars returns the character ranges of the first character re matches,
+// if re always matches at least one character.
+func firstChars(re *syntax.Regexp) ([]rune, bool) {
+	switch re.Op {
+	case syntax.OpCapture:
+		return firstChars(re.Sub[0])
+	case syntax.OpConcat:
+		if len(re.Sub) > 0 {
+			return firstChars(re.Sub[0])
+		}
+	case syntax.OpLiteral:
+		if len(re.Rune) > 0 {
+			return literalSet(re.Rune[0], re.Flags), true
+		}
+	case syntax.OpPlus:
+		return firstChars(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min >= 1 {
+			return firstChars(re.Sub[0])
+		}
+	default:
+		return charSet(re)
+	}
+	return nil, false
+}
+
+// overlaps reports whether the character ranges a and b share a character.
+func overlaps(a []rune, b []rune) bool {
+	for i := 0; i+1 < len(a); i += 2 {
+		for j := 0; j+1 < len(b); j += 2 {
+			if a[i] <= b[j+1] && b[j] <= a[i+1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ambiguousRepeat returns the first unbounded repetition in body, the body of
+// an unbounded repetition, whose text could also be matched by the
+// repetition's next iteration or by the rest of the body. A nested repetition
+// is unambiguous if it repeats a single character and is always followed by a
+// character it cannot match: the next element of body or, for the last
+// element, the first element of the next iteration. This admits patterns like
+// `(\d+\.)+` and `(\w+/)*`, but not `(a+)+` or `(a+ba+)+`.
+func ambiguousRepeat(body *syntax.Regexp) *syntax.Regexp {
+	elements := sequence(body)
+	for i, element := range elements {
+		if !isUnboundedRepeat(element) {
+			// Conservatively reject repetitions under alternations, optional
+			// groups, and bounded repetitions.
+			if repeat := firstUnboundedRepeat(element); repeat != nil {
+				return repeat
+			}
+			continue
+		}
+		repeated, ok := charSet(element.Sub[0])
+		if !ok {
+			return element
+		}
+		next, ok := firstChars(elements[(i+1)%len(elements)])
+		if !ok || overlaps(repeated, next) {
+			return element
+		}
+	}
+	return nil
+}
+
+// nestedRepeat returns the first unbounded repetition nested in an unbounded
+// repetition that can match the same text in more than one way (e.g.
+// "(a+)+"). Backtracking engines, such as the P0 backend's, can take
+// exponential time to reject such patterns.
+func nestedRepeat(re *syntax.Regexp) *syntax.Regexp {
+	if isUnboundedRepeat(re) {
+		if nested := ambiguousRepeat(re.Sub[0]); nested != nil {
+			return nested
+		}
+	}
+	for _, sub := range re.Sub {
+		if nested := nestedRepeat(sub); nested != nil {
+			return nested
+		}
+	}
+	return nil
+}
+
+// CheckPattern validates a resource-matching regular expression. It returns an
+// error if the pattern is invalid, uses syntax outside the supported dialect,
+// or is prone to catastrophic backtracking; otherwise it returns a warning if
+// the pattern matches every string (and so does not restrict anything), or "".
+func CheckPattern(pattern string) (warning string, err error) {
+	if reason := dialectError(pattern); reason != "" {
+		return "", fmt.Errorf("%s", reason)
+	}
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	if nested := nestedRepeat(parsed); nested != nil {
+		return "", fmt.Errorf("`%s` is repeated inside another repetition that can match the same text in more than one way, which can cause catastrophic backtracking; follow it with a character it cannot match (e.g. `(\\d+\\.)+`), or remove the inner or outer quantifier", nested)
+	}
+	re := regexp.MustCompile(pattern)
+	for _, probe := range matchAllProbes {
+		if !re.MatchString(probe) {
+			return "", nil
+		}
+	}
+	return fmt.Sprintf("Pattern %q matches every value; since patterns are unanchored, it does not restrict anything. If this is intended, omit the pattern instead.", pattern), nil
+}
+
+type patternValidator struct{}
+
+// PatternValidator returns a validator for attributes holding regular
+// expressions that P0 evaluates; see CheckPattern.
+func PatternValidator() validator.String {
+	return patternValidator{}
+}
+
+func (v patternValidator) Description(_ context.Context) string {
+	return "value must be a regular expression in the syntax shared by RE2 and JavaScript"
+}
+
+func (v patternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v patternValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	warning, err := CheckPattern(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid pattern", fmt.Sprintf("%q is not a valid pattern: %s.", req.ConfigValue.ValueString(), err))
+		return
+	}
+	if warning != "" {
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Pattern matches everything", warning)
+	}
+}
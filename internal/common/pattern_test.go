@@ -0,0 +1,64 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPattern(t *testing.T) {
+	cases := []struct {
+		pattern     string
+		wantErr     string
+		wantWarning bool
+	}{
+		{pattern: `^prod-`},
+		{pattern: `^repo:acme/[^:]+:ref:refs/heads/main$`},
+		{pattern: `(?<env>prod|staging)-\d+`},
+		{pattern: `[\]a-z]+`},
+		{pattern: `.*`, wantWarning: true},
+		{pattern: ``, wantWarning: true},
+		{pattern: `(?i)^prod-`},
+		{pattern: `^(?i:prod)-\d+`},
+		{pattern: `(?s).*`, wantErr: "inline flag"},
+		{pattern: `(?im)^prod`, wantErr: "inline flag"},
+		{pattern: `(`, wantErr: "missing closing )"},
+		{pattern: `(?=prod)`, wantErr: "invalid or unsupported Perl syntax"},
+		{pattern: `(?P<env>prod)`, wantErr: "named groups"},
+		{pattern: `\Aprod\z`, wantErr: "`\\A` and `\\z`"},
+		{pattern: `[[:alpha:]]+`, wantErr: "POSIX character classes"},
+		{pattern: `[]a]`, wantErr: "must be escaped"},
+		{pattern: `\p{L}+`, wantErr: "Unicode character classes"},
+		{pattern: `^(a+)+$`, wantErr: "catastrophic backtracking"},
+		{pattern: `(?:x*y?)*z`, wantErr: "catastrophic backtracking"},
+		{pattern: `(ab{2,}bc)+`, wantErr: "catastrophic backtracking"},
+		{pattern: `(a+ba+)+`, wantErr: "catastrophic backtracking"},
+		{pattern: `(\w+\d)+`, wantErr: "catastrophic backtracking"},
+		{pattern: `(?i)(B+b)+`, wantErr: "catastrophic backtracking"},
+		{pattern: `(B+b)+`},
+		{pattern: `((a+)+b)+`, wantErr: "catastrophic backtracking"},
+		{pattern: `(ab{2,}c)+`},
+		{pattern: `(ab{2,5}c)+`},
+		{pattern: `^(\d+\.)+\d+$`},
+		{pattern: `^([a-z0-9]+-)*[a-z0-9]+$`},
+		{pattern: `^arn:aws:iam::\d+:role/(\w+/)*Admin$`},
+		{pattern: `(?i)^arn:aws:iam::\d+:role/(\w+/)*admin$`},
+		{pattern: `^(-\d+)+$`},
+	}
+	for _, c := range cases {
+		t.Run(c.pattern, func(t *testing.T) {
+			warning, err := CheckPattern(c.pattern)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("error = %v; want it to contain %q", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if (warning != "") != c.wantWarning {
+				t.Errorf("warning = %q; want a warning: %v", warning, c.wantWarning)
+			}
+		})
+	}
+}
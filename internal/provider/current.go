@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc. and P0 Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &Current{}
+var _ datasource.DataSourceWithConfigure = &Current{}
+
+func NewCurrent() datasource.DataSource {
+	return &Current{}
+}
+
+// Current exposes the organization, host, and credential source the provider is
+// configured with, and the caller's identity and roles as reported by P0.
+type Current struct {
+	data *internal.P0ProviderData
+}
+
+type currentModel struct {
+	Org        string       `tfsdk:"org"`
+	Host       string       `tfsdk:"host"`
+	AuthSource string       `tfsdk:"auth_source"`
+	Identity   types.String `tfsdk:"identity"`
+	Roles      types.List   `tfsdk:"roles"`
+}
+
+// callerPath is the P0 API endpoint describing the authenticated caller.
+const callerPath = "whoami"
+
+// callerApi is the response of GET whoami: the principal P0 authenticated the
+// bearer token as, and the P0 roles bound to it (e.g. "owner", "manager").
+type callerApi struct {
+	Identity string   `json:"identity"`
+	Roles    []string `json:"roles"`
+}
+
+// errCallerUnavailable reports that the P0 API does not serve the caller
+// endpoint.
+var errCallerUnavailable = errors.New("the P0 API did not find the caller endpoint (GET whoami)")
+
+// readCaller fetches the caller's identity and roles. Since the caller is by
+// definition authenticated, a 404 means the endpoint itself is unavailable.
+func readCaller(data *internal.P0ProviderData) (*callerApi, error) {
+	var json callerApi
+	httpResp, err := data.Get(callerPath, &json)
+	if httpResp != nil && httpResp.StatusCode == 404 {
+		return nil, errCallerUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &json, nil
+}
+
+func (d *Current) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current"
+}
+
+func (d *Current) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The P0 organization and caller identity the provider is configured with.
+
+Use this data source to name or tag resources by organization, or to guard against applying a configuration to the
+wrong organization (for example with a ` + "`precondition`" + ` on ` + "`org`" + `).
+
+The caller's identity and roles are those P0 authenticates the provider's credential as. If the P0 API does not serve
+them, both are null and a warning is emitted.`,
+		Attributes: map[string]schema.Attribute{
+			"org": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The P0 organization identifier",
+			},
+			"host": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The P0 API host",
+			},
+			"auth_source": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: `Where the provider's credential was read from. One of:
+	- 'api_token': The ` + "`api_token`" + ` provider attribute
+	- 'P0_API_TOKEN': The ` + "`P0_API_TOKEN`" + ` environment variable
+	- 'cli': The P0 CLI login session`,
+			},
+			"identity": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The caller's identity: the user's email address for a P0 CLI login, else the API token's owner.",
+			},
+			"roles": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: `The caller's P0 roles, as backend role identifiers. Known roles are:
+	- 'owner': Owner (see ` + "`p0_owner_user`" + `)
+	- 'manager': Security Reviewer (see ` + "`p0_security_reviewer_user`" + `)
+	- 'iamOwner': Assessment User (see ` + "`p0_assessment_user`" + `)
+	- 'iamViewer': Assessment Viewer (see ` + "`p0_assessment_viewer_user`" + `)`,
+			},
+		},
+	}
+}
+
+func (d *Current) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	data := internal.ConfigureDataSource(&req, resp)
+	if data != nil {
+		d.data = data
+	}
+}
+
+func (d *Current) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	model := currentModel{
+		Org:        d.data.Org,
+		Host:       d.data.Host,
+		AuthSource: d.data.AuthSource,
+		Identity:   types.StringNull(),
+		Roles:      types.ListNull(types.StringType),
+	}
+
+	caller, err := readCaller(d.data)
+	if errors.Is(err, errCallerUnavailable) {
+		resp.Diagnostics.AddWarning("Caller identity unavailable", fmt.Sprintf("The caller's identity and roles are null:\n%s", err))
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to read the caller's identity:\n%s", err))
+		return
+	} else {
+		if caller.Identity != "" {
+			model.Identity = types.StringValue(caller.Identity)
+		}
+		roles, diags := types.ListValueFrom(ctx, types.StringType, append([]string{}, caller.Roles...))
+		resp.Diagnostics.Append(diags...)
+		model.Roles = roles
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
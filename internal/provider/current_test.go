@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc. and P0 Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+// TestReadCaller verifies that the caller's identity and roles are decoded, and
+// that a missing caller endpoint is reported as such.
+func TestReadCaller(t *testing.T) {
+	found := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !found || r.Method != http.MethodGet || r.URL.Path != "/whoami" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer x" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"identity": "alice@example.com", "roles": ["owner", "iamViewer"]}`))
+	}))
+	t.Cleanup(server.Close)
+	data := &internal.P0ProviderData{BaseUrl: server.URL, Authentication: "Bearer x", Client: server.Client()}
+
+	caller, err := readCaller(data)
+	if err != nil {
+		t.Fatalf("readCaller() error = %v", err)
+	}
+	want := &callerApi{Identity: "alice@example.com", Roles: []string{"owner", "iamViewer"}}
+	if !reflect.DeepEqual(caller, want) {
+		t.Errorf("readCaller() = %+v; want %+v", caller, want)
+	}
+
+	data.Authentication = "Bearer y"
+	if _, err := readCaller(data); err == nil || errors.Is(err, errCallerUnavailable) {
+		t.Errorf("readCaller() with a rejected token error = %v; want a request error", err)
+	}
+
+	found = false
+	if _, err := readCaller(data); !errors.Is(err, errCallerUnavailable) {
+		t.Errorf("readCaller() error = %v; want %v", err, errCallerUnavailable)
+	}
+}
@@ -93,24 +93,31 @@ identified in P0 API logs.`,
 	}
 }
 
-// resolveApiToken returns the P0 API token to authenticate with, consulting the
-// following sources in order of precedence: the api_token provider attribute,
-// the P0_API_TOKEN environment variable, and the P0 CLI session (whose OIDC
-// credential is exchanged for a Firebase ID token).
-func resolveApiToken(ctx context.Context, model P0ProviderModel, diags *diag.Diagnostics) string {
-	var token string
+// Authentication sources reported by resolveApiToken, in order of precedence.
+const (
+	authSourceAttribute = "api_token"
+	authSourceEnv       = "P0_API_TOKEN"
+	authSourceCli       = "cli"
+)
+
+// resolveApiToken returns the P0 API token to authenticate with, and the source
+// it was read from, consulting the following sources in order of precedence:
+// the api_token provider attribute, the P0_API_TOKEN environment variable, and
+// the P0 CLI session (whose OIDC credential is exchanged for a Firebase ID token).
+func resolveApiToken(ctx context.Context, model P0ProviderModel, diags *diag.Diagnostics) (string, string) {
+	var token, source string
 	if !model.ApiToken.IsNull() && !model.ApiToken.IsUnknown() {
-		token = model.ApiToken.ValueString()
+		token, source = model.ApiToken.ValueString(), authSourceAttribute
 	} else if envToken, ok := os.LookupEnv("P0_API_TOKEN"); ok {
-		token = envToken
+		token, source = envToken, authSourceEnv
 	} else {
 		cliToken, err := cliFirebaseToken(ctx)
 		if err != nil && !errors.Is(err, errNoCliSession) {
 			diags.AddError("Could not authenticate using the P0 CLI session", err.Error())
-			return ""
+			return "", ""
 		}
 		// A missing CLI session falls through to the "no auth configured" error below.
-		token = cliToken
+		token, source = cliToken, authSourceCli
 	}
 	if token == "" {
 		diags.AddError(
@@ -121,7 +128,7 @@ func resolveApiToken(ctx context.Context, model P0ProviderModel, diags *diag.Dia
 			),
 		)
 	}
-	return token
+	return token, source
 }
 
 func (p *P0Provider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -136,7 +143,7 @@ func (p *P0Provider) Configure(ctx context.Context, req provider.ConfigureReques
 		)
 	}
 
-	api_token := resolveApiToken(ctx, model, &resp.Diagnostics)
+	api_token, auth_source := resolveApiToken(ctx, model, &resp.Diagnostics)
 
 	p0_host := model.Host.ValueString()
 	if p0_host == "" {
@@ -152,6 +159,10 @@ func (p *P0Provider) Configure(ctx context.Context, req provider.ConfigureReques
 		UserAgent:      fmt.Sprintf("terraform-provider-p0/%s Terraform/%s", p.version, req.TerraformVersion),
 		Client:         http.DefaultClient,
 		BaseUrl:        fmt.Sprintf("%s/o/%s", p0_host, model.Org.ValueString()),
+		Org:            model.Org.ValueString(),
+		Host:           p0_host,
+		AuthSource:     auth_source,
+		Cache:          internal.NewResponseCache(),
 	}
 	resp.DataSourceData = data
 	resp.ResourceData = data
@@ -160,6 +171,9 @@ func (p *P0Provider) Configure(ctx context.Context, req provider.ConfigureReques
 func (p *P0Provider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		accesspolicy.NewAccessPolicy,
+		accesspolicy.NewAccessPolicies,
+		accesspolicy.NewAccessPolicyDocument,
+		accesspolicy.NewPrincipalSet,
 		accesspolicy.NewRoutingRule, // deprecated alias of p0_access_policy
 		settings.NewOwnerUser,
 		settings.NewOwnerGroup,
@@ -226,11 +240,29 @@ func (p *P0Provider) Resources(ctx context.Context) []func() resource.Resource {
 }
 
 func (p *P0Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewCurrent,
+		accesspolicy.NewAccessPoliciesDataSource,
+		settings.NewSettings,
+		installagentic.NewGatewayDataSource,
+		installagentic.NewServersDataSource,
+		installgcp.NewGcpPermissions,
+		installk8s.NewKubernetesManifests,
+	}
 }
 
 func (p *P0Provider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		accesspolicy.NewUserRequestorFunction,
+		accesspolicy.NewGroupRequestorFunction,
+		accesspolicy.NewAgenticRequestorFunction,
+		accesspolicy.NewGroupApprovalFunction,
+		accesspolicy.NewEvaluatePolicyFunction,
+		installaws.NewRoleArnFunction,
+		installaws.NewMergeTrustPolicyFunction,
+		settings.NewDurationFunction,
+		settings.NewDurationLabelFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
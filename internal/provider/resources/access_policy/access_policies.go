@@ -0,0 +1,466 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+var _ resource.Resource = &AccessPolicies{}
+var _ resource.ResourceWithConfigure = &AccessPolicies{}
+var _ resource.ResourceWithImportState = &AccessPolicies{}
+var _ resource.ResourceWithModifyPlan = &AccessPolicies{}
+
+const (
+	// In authoritative mode, policies not declared in Terraform are deleted.
+	modeAuthoritative = "authoritative"
+	// In report mode, policies not declared in Terraform are only reported.
+	modeReport = "report"
+)
+
+// AccessPolicies manages the organization's complete set of access policies.
+// It is a singleton: declare it at most once, and do not combine it with
+// p0_access_policy resources.
+type AccessPolicies struct {
+	data *internal.P0ProviderData
+}
+
+func NewAccessPolicies() resource.Resource {
+	return &AccessPolicies{}
+}
+
+// AccessPolicySetEntryModel is a single policy of a p0_access_policies
+// resource; its name is its key in the `policies` map.
+type AccessPolicySetEntryModel struct {
+	Id         types.String      `tfsdk:"id"`
+	Disabled   *bool             `tfsdk:"disabled"`
+	Requestor  *RequestorModelV5 `tfsdk:"requestor"`
+	Resource   *ResourceModel    `tfsdk:"resource"`
+	Approval   []ApprovalModelV5 `tfsdk:"approval"`
+	Schedule   *ScheduleModel    `tfsdk:"schedule"`
+	BreakGlass *BreakGlassModel  `tfsdk:"break_glass"`
+
+	Notifications []NotificationModel `tfsdk:"notifications"`
+}
+
+type AccessPoliciesModel struct {
+	Policies  map[string]AccessPolicySetEntryModel `tfsdk:"policies"`
+	Mode      types.String                         `tfsdk:"mode"`
+	Unmanaged types.List                           `tfsdk:"unmanaged"`
+}
+
+// AccessPoliciesJson is the response of the policy list endpoint.
+type AccessPoliciesJson struct {
+	Policies []AccessPolicyJson `json:"policies"`
+}
+
+func entryToModel(name string, entry AccessPolicySetEntryModel) AccessPolicyModelV11 {
+	return AccessPolicyModelV11{
+		Id:            entry.Id,
+		Name:          &name,
+		Disabled:      entry.Disabled,
+		Requestor:     entry.Requestor,
+		Resource:      entry.Resource,
+		Approval:      entry.Approval,
+		Schedule:      entry.Schedule,
+		BreakGlass:    entry.BreakGlass,
+		Notifications: entry.Notifications,
+	}
+}
+
+func entryFromModel(model AccessPolicyModelV11) AccessPolicySetEntryModel {
+	return AccessPolicySetEntryModel{
+		Id:            model.Id,
+		Disabled:      model.Disabled,
+		Requestor:     model.Requestor,
+		Resource:      model.Resource,
+		Approval:      model.Approval,
+		Schedule:      model.Schedule,
+		BreakGlass:    model.BreakGlass,
+		Notifications: model.Notifications,
+	}
+}
+
+// undeclaredPolicies returns the sorted names of existing policies that are
+// not declared.
+func undeclaredPolicies(existing map[string]AccessPolicyJson, declared map[string]AccessPolicySetEntryModel) []string {
+	undeclared := []string{}
+	for name := range existing {
+		if _, ok := declared[name]; !ok {
+			undeclared = append(undeclared, name)
+		}
+	}
+	slices.Sort(undeclared)
+	return undeclared
+}
+
+func sortedNames[V any](policies map[string]V) []string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (r *AccessPolicies) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_policies"
+}
+
+func (r *AccessPolicies) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The complete set of your organization's access policies. This is a singleton resource; declare it at most once,
+and do not use it together with ` + "`p0_access_policy`" + ` resources.
+
+Policies that are not declared here (e.g., created in the P0 app) are deleted, or, with ` + "`mode = \"report\"`" + `, reported
+as warnings when planning. Import this resource (with any ID) to adopt all existing policies. If it is created instead,
+existing policies that are not declared are kept and listed in ` + "`unmanaged`" + `, and (in 'authoritative' mode) deleted by
+the next apply. The plan also warns
+about declared policies that are shadowed by, contradict, or duplicate one another.
+See [the P0 access-policy docs](https://docs.p0.dev/just-in-time-access/request-routing).`,
+		Attributes: map[string]schema.Attribute{
+			"policies": schema.MapNestedAttribute{
+				MarkdownDescription: "The access policies, keyed by policy name. Changing a key deletes the policy and creates a new one.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The policy's identifier in P0",
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether or not the access policy should be evaluated; if false or not defined, the policy will be evaluated",
+							Optional:            true,
+						},
+						"requestor":     requestorAttribute(currentSchemaVersion),
+						"resource":      resourceAttribute,
+						"approval":      approvalAttribute(currentSchemaVersion),
+						"schedule":      scheduleAttribute(),
+						"break_glass":   breakGlassAttribute(),
+						"notifications": policyNotificationsAttribute(),
+					},
+				},
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: `How policies not declared in 'policies' are handled. May be one of:
+    - 'authoritative': Undeclared policies are deleted (default)
+    - 'report': Undeclared policies are kept, and listed as a warning when planning`,
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(modeAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(modeAuthoritative, modeReport),
+				},
+			},
+			"unmanaged": schema.ListAttribute{
+				MarkdownDescription: "The names of existing policies that are not declared in 'policies'. In 'authoritative' mode, always empty after any apply but the one that creates this resource.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *AccessPolicies) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := internal.Configure(&req, resp)
+	if data != nil {
+		r.data = data
+	}
+}
+
+// list returns all of the organization's access policies, keyed by name.
+func (r *AccessPolicies) list(diags *diag.Diagnostics) map[string]AccessPolicyJson {
+	existing, _, err := listPolicies(r.data)
+	if err != nil {
+		diags.AddError("Error communicating with P0", fmt.Sprintf("Unable to list access policies:\n%s", err))
+		return nil
+	}
+	return existing
+}
+
+func (r *AccessPolicies) delete(ctx context.Context, diags *diag.Diagnostics, name string) {
+	_, err := r.data.Delete(getPath(name))
+	if err != nil {
+		diags.AddError("Error communicating with P0", fmt.Sprintf("Unable to delete access policy %q:\n%s", name, err))
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Deleted access policy %q", name))
+}
+
+// apply writes every planned policy, deletes the policies removed from the
+// plan since prior, and (in authoritative mode) deletes undeclared policies.
+// When creating, undeclared policies are kept in either mode, so that they
+// are only deleted by an apply whose plan has listed them.
+//
+// If a request fails, apply returns the policies as far as it got: the
+// written policies, plus the prior policies that it had not yet written or
+// deleted. created lists the policies that apply created, so that a failed
+// Create can roll them back.
+func (r *AccessPolicies) apply(ctx context.Context, diags *diag.Diagnostics, plan AccessPoliciesModel, prior map[string]AccessPolicySetEntryModel, creating bool) (result AccessPoliciesModel, created []string) {
+	result = AccessPoliciesModel{Policies: map[string]AccessPolicySetEntryModel{}, Mode: plan.Mode, Unmanaged: types.ListNull(types.StringType)}
+	deleted := map[string]bool{}
+	defer func() {
+		if !diags.HasError() {
+			return
+		}
+		for name, entry := range prior {
+			if _, written := result.Policies[name]; !written && !deleted[name] {
+				result.Policies[name] = entry
+			}
+		}
+	}()
+
+	existing := r.list(diags)
+	if diags.HasError() {
+		return result, nil
+	}
+
+	for _, name := range sortedNames(plan.Policies) {
+		json := toJson(entryToModel(name, plan.Policies[name]))
+		var updatedJson AccessPolicyJson
+		var err error
+		_, exists := existing[name]
+		if exists {
+			_, err = r.data.Put(getPath(name), &json, &updatedJson)
+		} else {
+			_, err = r.data.Post(getPath(name), &json, &updatedJson)
+		}
+		if err != nil {
+			diags.AddError("Error communicating with P0", fmt.Sprintf("Unable to write access policy %q:\n%s", name, err))
+			return result, created
+		}
+		if !exists {
+			created = append(created, name)
+		}
+		result.Policies[name] = entryFromModel(toModel(updatedJson))
+	}
+
+	undeclared := []string{}
+	for _, name := range undeclaredPolicies(existing, plan.Policies) {
+		// Policies removed from the configuration are always deleted; other
+		// undeclared policies are only deleted in authoritative mode.
+		if _, managed := prior[name]; !managed && (creating || plan.Mode.ValueString() == modeReport) {
+			undeclared = append(undeclared, name)
+			continue
+		}
+		r.delete(ctx, diags, name)
+		if diags.HasError() {
+			return result, created
+		}
+		deleted[name] = true
+	}
+
+	unmanaged, listDiags := types.ListValueFrom(ctx, types.StringType, undeclared)
+	diags.Append(listDiags...)
+	result.Unmanaged = unmanaged
+	return result, created
+}
+
+func (r *AccessPolicies) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AccessPoliciesModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, created := r.apply(ctx, &resp.Diagnostics, plan, nil, true)
+	if resp.Diagnostics.HasError() {
+		// A failed Create leaves no state, so roll back the policies it
+		// created rather than leave them unmanaged. Existing policies that
+		// were overwritten are kept, and are written again by the next apply.
+		for _, name := range created {
+			r.delete(ctx, &resp.Diagnostics, name)
+		}
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
+
+func (r *AccessPolicies) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccessPoliciesModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing := r.list(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies := map[string]AccessPolicySetEntryModel{}
+	for name, json := range existing {
+		// Null policies means the resource was just imported; adopt every policy.
+		if _, ok := state.Policies[name]; ok || state.Policies == nil {
+			policies[name] = entryFromModel(toModel(json))
+		}
+	}
+	state.Policies = policies
+	if state.Mode.IsNull() {
+		state.Mode = types.StringValue(modeAuthoritative)
+	}
+
+	unmanaged, diags := types.ListValueFrom(ctx, types.StringType, undeclaredPolicies(existing, policies))
+	resp.Diagnostics.Append(diags...)
+	state.Unmanaged = unmanaged
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *AccessPolicies) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AccessPoliciesModel
+	var state AccessPoliciesModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// On failure, the state records the policies written so far, so that the
+	// next plan only retries the rest.
+	result, _ := r.apply(ctx, &resp.Diagnostics, plan, state.Policies, false)
+	if resp.Diagnostics.HasError() {
+		result.Unmanaged = state.Unmanaged
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
+
+func (r *AccessPolicies) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AccessPoliciesModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the declared policies are deleted; unmanaged policies are left as-is.
+	for _, name := range sortedNames(state.Policies) {
+		r.delete(ctx, &resp.Diagnostics, name)
+	}
+}
+
+func (r *AccessPolicies) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Leave `policies` null so that Read adopts every existing policy.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mode"), modeAuthoritative)...)
+}
+
+// plannedUndeclared returns the existing policies that the planned `policies`
+// do not declare, or nil if the planned names aren't known yet.
+func (r *AccessPolicies) plannedUndeclared(ctx context.Context, req resource.ModifyPlanRequest, diags *diag.Diagnostics) []string {
+	var policies types.Map
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("policies"), &policies)...)
+	if diags.HasError() || policies.IsUnknown() {
+		return nil
+	}
+	existing := r.list(diags)
+	if diags.HasError() {
+		return nil
+	}
+	declared := map[string]AccessPolicySetEntryModel{}
+	for name := range policies.Elements() {
+		declared[name] = AccessPolicySetEntryModel{}
+	}
+	return undeclaredPolicies(existing, declared)
+}
+
+// ModifyPlan plans the deletion of unmanaged policies in authoritative mode,
+// warns about them in report mode, and validates each policy's `resource` and
+// notifications against the organization's installed integrations.
+func (r *AccessPolicies) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.data == nil {
+		return
+	}
+
+	creating := req.State.Raw.IsNull()
+	var mode types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("mode"), &mode)...)
+	var unmanaged []string
+	if creating {
+		// There is no record of unmanaged policies until the resource exists.
+		unmanaged = r.plannedUndeclared(ctx, req, &resp.Diagnostics)
+	} else {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("unmanaged"), &unmanaged)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case creating:
+		// Creating keeps undeclared policies in either mode (see apply), so
+		// `unmanaged` is left unknown until they are listed again.
+		if len(unmanaged) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Undeclared access policies",
+				fmt.Sprintf("These access policies already exist, but are not declared in 'policies': %s. They are kept when this resource is created, and listed in 'unmanaged'. In 'authoritative' mode, the next apply deletes them; to adopt them instead, import this resource.", strings.Join(unmanaged, ", ")),
+			)
+		}
+	case mode.ValueString() == modeAuthoritative:
+		// Forces an update, which deletes the unmanaged policies.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged"), []string{})...)
+		if len(unmanaged) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Undeclared access policies will be deleted",
+				fmt.Sprintf("These access policies are not declared in 'policies', and will be deleted: %s", strings.Join(unmanaged, ", ")),
+			)
+		}
+	case mode.ValueString() == modeReport:
+		if len(unmanaged) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Undeclared access policies",
+				fmt.Sprintf("These access policies are not declared in 'policies': %s", strings.Join(unmanaged, ", ")),
+			)
+		}
+	}
+
+	// Undeclared policies remain in effect unless they are about to be deleted.
+	kept := creating || mode.ValueString() == modeReport
+	r.analyzeConflicts(ctx, req, resp, unmanaged, kept)
+
+	var policies types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("policies"), &policies)...)
+	if resp.Diagnostics.HasError() || policies.IsUnknown() {
+		return
+	}
+	plans := map[string]policyIntegrationsPlan{}
+	for name, value := range policies.Elements() {
+		entry, ok := value.(types.Object)
+		if !ok || entry.IsUnknown() {
+			continue
+		}
+		plan, diags := newPolicyIntegrationsPlan(ctx, entry.Attributes(), path.Root("policies").AtMapKey(name))
+		resp.Diagnostics.Append(diags...)
+		if plan.needsIntegrations() {
+			plans[name] = plan
+		}
+	}
+	if resp.Diagnostics.HasError() || len(plans) == 0 {
+		return
+	}
+	installed, ok := getInstalledIntegrations(ctx, r.data, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	for _, name := range sortedNames(plans) {
+		resp.Diagnostics.Append(plans[name].validate(installed)...)
+	}
+}
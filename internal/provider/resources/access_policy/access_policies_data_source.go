@@ -0,0 +1,99 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+var _ datasource.DataSource = &AccessPoliciesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AccessPoliciesDataSource{}
+
+// AccessPoliciesDataSource lists the organization's access policies, e.g. to
+// write `import` blocks for policies created in the P0 app.
+type AccessPoliciesDataSource struct {
+	data *internal.P0ProviderData
+}
+
+func NewAccessPoliciesDataSource() datasource.DataSource {
+	return &AccessPoliciesDataSource{}
+}
+
+type accessPolicySummaryModel struct {
+	Id       *string `tfsdk:"id"`
+	Name     string  `tfsdk:"name"`
+	Disabled bool    `tfsdk:"disabled"`
+}
+
+type accessPoliciesDataModel struct {
+	Policies []accessPolicySummaryModel `tfsdk:"policies"`
+}
+
+func (d *AccessPoliciesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_policies"
+}
+
+func (d *AccessPoliciesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `All of your organization's access policies, including those created in the P0 app.
+
+Use this data source to find policies to bring under Terraform management: import each with an ` + "`import`" + ` block whose
+` + "`id`" + ` is the policy's name or id, and run ` + "`terraform plan -generate-config-out=policies.tf`" + ` to generate their
+configuration.`,
+		Attributes: map[string]schema.Attribute{
+			"policies": schema.ListNestedAttribute{
+				MarkdownDescription: "The access policies, ordered by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The policy's identifier in P0. Unlike `name`, this does not change when the policy is renamed.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the policy.",
+							Computed:            true,
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the policy is disabled.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AccessPoliciesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := internal.ConfigureDataSource(&req, resp)
+	if data != nil {
+		d.data = data
+	}
+}
+
+func (d *AccessPoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	existing, _, err := listPolicies(d.data)
+	if err != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to list access policies:\n%s", err))
+		return
+	}
+
+	model := accessPoliciesDataModel{Policies: []accessPolicySummaryModel{}}
+	for _, name := range sortedNames(existing) {
+		policy := existing[name]
+		model.Policies = append(model.Policies, accessPolicySummaryModel{
+			Id:       policy.Id,
+			Name:     name,
+			Disabled: policy.Disabled != nil && *policy.Disabled,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
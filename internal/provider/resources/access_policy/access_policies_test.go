@@ -0,0 +1,215 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUndeclaredPolicies(t *testing.T) {
+	existing := map[string]AccessPolicyJson{
+		"ui-created": {Name: strPtr("ui-created")},
+		"eng":        {Name: strPtr("eng")},
+		"another-ui": {Name: strPtr("another-ui")},
+	}
+	declared := map[string]AccessPolicySetEntryModel{
+		"eng": {},
+		"new": {},
+	}
+
+	got := undeclaredPolicies(existing, declared)
+
+	if want := []string{"another-ui", "ui-created"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("undeclaredPolicies = %v; want %v", got, want)
+	}
+	if got := undeclaredPolicies(nil, declared); got == nil || len(got) != 0 {
+		t.Errorf("undeclaredPolicies(nil) = %#v; want an empty, non-nil list", got)
+	}
+}
+
+// TestEntryModelRoundTrip verifies a policy entry survives conversion to the
+// p0_access_policy model, which supplies its name from the map key.
+func TestEntryModelRoundTrip(t *testing.T) {
+	entry := AccessPolicySetEntryModel{
+		Id:        types.StringValue("pol_123"),
+		Requestor: &RequestorModelV5{Type: "any"},
+		Resource:  &ResourceModel{Type: "any"},
+		Approval:  []ApprovalModelV5{{Type: "p0"}},
+	}
+
+	model := entryToModel("eng", entry)
+	if model.Name == nil || *model.Name != "eng" {
+		t.Fatalf("Name = %v; want %q", model.Name, "eng")
+	}
+	if got := entryFromModel(toModel(toJson(model))); !reflect.DeepEqual(got.Approval, entry.Approval) || got.Requestor.Type != "any" {
+		t.Errorf("round-trip = %+v; want %+v", got, entry)
+	}
+}
+
+const existingPoliciesJson = `{"policies": [
+	{"id": "pol_1", "name": "eng", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []},
+	{"id": "pol_2", "name": "ui-created", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}
+]}`
+
+// accessPoliciesPlan is a plan declaring only the "eng" policy.
+func accessPoliciesPlan(t *testing.T, r *AccessPolicies) (tfsdk.Plan, tfsdk.State) {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	model := AccessPoliciesModel{
+		Policies: map[string]AccessPolicySetEntryModel{
+			"eng": {
+				Id:        types.StringUnknown(),
+				Requestor: &RequestorModelV5{Type: "any"},
+				Resource:  &ResourceModel{Type: "any"},
+				Approval:  []ApprovalModelV5{},
+			},
+		},
+		Mode:      types.StringValue(modeAuthoritative),
+		Unmanaged: types.ListUnknown(types.StringType),
+	}
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("plan.Set: %v", diags)
+	}
+	return plan, tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+}
+
+// TestAccessPoliciesCreateKeepsUndeclared verifies that creating the resource
+// in authoritative mode warns about, but does not delete, existing policies
+// that it doesn't declare.
+func TestAccessPoliciesCreateKeepsUndeclared(t *testing.T) {
+	ctx := context.Background()
+	api := &policyApi{responses: map[string]string{
+		"GET /policy":          existingPoliciesJson,
+		"PUT /policy/name/eng": `{"id": "pol_1", "name": "eng", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}`,
+	}}
+	r := &AccessPolicies{data: api.start(t).data}
+	plan, nullState := accessPoliciesPlan(t, r)
+
+	planResp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: nullState}, planResp)
+	if planResp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan: %v", planResp.Diagnostics)
+	}
+	// The kept policy is also compared with the declared one, which it duplicates.
+	var summaries []string
+	for _, warning := range planResp.Diagnostics.Warnings() {
+		summaries = append(summaries, warning.Summary())
+		if warning.Summary() == "Undeclared access policies" && !strings.Contains(warning.Detail(), "ui-created") {
+			t.Errorf("warning %q does not name ui-created", warning.Detail())
+		}
+	}
+	if want := []string{"Undeclared access policies", "Duplicate access policies"}; !slices.Equal(summaries, want) {
+		t.Errorf("warnings = %v; want %v", summaries, want)
+	}
+	var unmanaged types.List
+	planResp.Diagnostics.Append(planResp.Plan.GetAttribute(ctx, path.Root("unmanaged"), &unmanaged)...)
+	if !unmanaged.IsUnknown() {
+		t.Errorf("planned unmanaged = %v; want unknown until apply", unmanaged)
+	}
+
+	createResp := &resource.CreateResponse{State: nullState}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	for _, request := range api.requests {
+		if strings.HasPrefix(request, "DELETE ") {
+			t.Errorf("Create sent %q; want no deletions", request)
+		}
+	}
+	var state AccessPoliciesModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &state)...)
+	var names []string
+	createResp.Diagnostics.Append(state.Unmanaged.ElementsAs(ctx, &names, false)...)
+	if !slices.Equal(names, []string{"ui-created"}) {
+		t.Errorf("unmanaged = %v; want [ui-created]", names)
+	}
+}
+
+// TestAccessPoliciesCreateRollsBack verifies that when a write fails partway
+// through Create, the policies that Create already created are deleted.
+func TestAccessPoliciesCreateRollsBack(t *testing.T) {
+	ctx := context.Background()
+	api := &policyApi{responses: map[string]string{
+		"GET /policy":             `{"policies": []}`,
+		"POST /policy/name/eng":   `{"id": "pol_1", "name": "eng", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}`,
+		"POST /policy/name/ops":   "",
+		"DELETE /policy/name/eng": `{}`,
+	}}
+	r := &AccessPolicies{data: api.start(t).data}
+	plan, nullState := accessPoliciesPlan(t, r)
+	var model AccessPoliciesModel
+	plan.Get(ctx, &model)
+	model.Policies["ops"] = model.Policies["eng"]
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("plan.Set: %v", diags)
+	}
+
+	createResp := &resource.CreateResponse{State: nullState}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("Create succeeded; want an error")
+	}
+	want := []string{"GET /policy", "POST /policy/name/eng", "POST /policy/name/ops", "DELETE /policy/name/eng"}
+	if !slices.Equal(api.requests, want) {
+		t.Errorf("requests = %v; want %v", api.requests, want)
+	}
+}
+
+// TestAccessPoliciesUpdatePartialState verifies that when a write fails
+// partway through Update, the state records the policies written so far and
+// keeps the prior value of the rest.
+func TestAccessPoliciesUpdatePartialState(t *testing.T) {
+	ctx := context.Background()
+	api := &policyApi{responses: map[string]string{
+		"GET /policy":           `{"policies": [{"id": "pol_1", "name": "eng", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}]}`,
+		"PUT /policy/name/eng":  `{"id": "pol_1", "name": "eng", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}`,
+		"POST /policy/name/ops": "",
+	}}
+	r := &AccessPolicies{data: api.start(t).data}
+	plan, state := accessPoliciesPlan(t, r)
+	var model AccessPoliciesModel
+	plan.Get(ctx, &model)
+	model.Policies["ops"] = model.Policies["eng"]
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("plan.Set: %v", diags)
+	}
+	prior := AccessPoliciesModel{
+		Policies: map[string]AccessPolicySetEntryModel{
+			"eng": {Id: types.StringValue("pol_1"), Requestor: &RequestorModelV5{Type: "any"}, Resource: &ResourceModel{Type: "any"}, Approval: []ApprovalModelV5{}},
+		},
+		Mode:      types.StringValue(modeAuthoritative),
+		Unmanaged: types.ListValueMust(types.StringType, nil),
+	}
+	if diags := state.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("state.Set: %v", diags)
+	}
+
+	updateResp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, updateResp)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatal("Update succeeded; want an error")
+	}
+	var result AccessPoliciesModel
+	if diags := updateResp.State.Get(ctx, &result); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	if names := sortedNames(result.Policies); !slices.Equal(names, []string{"eng"}) {
+		t.Errorf("policies = %v; want [eng]", names)
+	}
+}
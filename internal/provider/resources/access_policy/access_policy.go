@@ -6,6 +6,7 @@ package accesspolicy
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/p0-security/terraform-provider-p0/internal"
 )
@@ -22,6 +24,7 @@ var _ resource.Resource = &AccessPolicy{}
 var _ resource.ResourceWithImportState = &AccessPolicy{}
 var _ resource.ResourceWithUpgradeState = &AccessPolicy{}
 var _ resource.ResourceWithMoveState = &AccessPolicy{}
+var _ resource.ResourceWithModifyPlan = &AccessPolicy{}
 
 type AccessPolicy struct {
 	data *internal.P0ProviderData
@@ -31,11 +34,20 @@ type AccessPolicy struct {
 // - In TF state, it may be present, unknown (during update), or null
 // - In JSON state, it is either present or null.
 type AccessPolicyJson struct {
-	Name      *string           `json:"name" tfsdk:"name"`
-	Disabled  *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
-	Requestor RequestorJson     `json:"requestor" tfsdk:"requestor"`
-	Resource  ResourceModel     `json:"resource" tfsdk:"resource"`
-	Approval  []ApprovalModelV2 `json:"approval" tfsdk:"approval"`
+	Id         *string           `json:"id,omitempty" tfsdk:"id"`
+	Name       *string           `json:"name" tfsdk:"name"`
+	Disabled   *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor  RequestorJson     `json:"requestor" tfsdk:"requestor"`
+	Resource   ResourceModel     `json:"resource" tfsdk:"resource"`
+	Approval   []ApprovalModelV5 `json:"approval" tfsdk:"approval"`
+	Schedule   *ScheduleModel    `json:"schedule,omitempty" tfsdk:"schedule"`
+	BreakGlass *BreakGlassModel  `json:"breakGlass,omitempty" tfsdk:"break_glass"`
+
+	Notifications []NotificationModel `json:"notifications,omitempty" tfsdk:"notifications"`
+
+	// Set by P0 on every change; ignored in requests.
+	LastModifiedBy *string `json:"lastModifiedBy,omitempty" tfsdk:"last_modified_by"`
+	LastModifiedAt *string `json:"lastModifiedAt,omitempty" tfsdk:"last_modified_at"`
 }
 
 // IdpGroupsJson mirrors the P0 app's `IdpGroups` wire shape: a `groups`+
@@ -66,6 +78,9 @@ type RequestorJson struct {
 	Effect *string           `json:"effect,omitempty"`
 	Agent  *AgentJson        `json:"agent,omitempty"`
 	User   *AgenticUserModel `json:"user,omitempty"`
+
+	PrincipalSet *string                `json:"principalSet,omitempty"`
+	Profile      *RequestorProfileModel `json:"profile,omitempty"`
 }
 
 // agentToJson wraps AgentModel's flat Groups/Effect into a nested
@@ -107,25 +122,29 @@ func agentFromJson(json *AgentJson) *AgentModel {
 	return agent
 }
 
-func requestorToJson(model *RequestorModelV3) RequestorJson {
+func requestorToJson(model *RequestorModelV5) RequestorJson {
 	return RequestorJson{
-		Type:   model.Type,
-		Groups: model.Groups,
-		Uid:    model.Uid,
-		Effect: model.Effect,
-		Agent:  agentToJson(model.Agent),
-		User:   model.User,
+		Type:         model.Type,
+		Groups:       model.Groups,
+		Uid:          model.Uid,
+		Effect:       model.Effect,
+		Agent:        agentToJson(model.Agent),
+		User:         model.User,
+		PrincipalSet: model.PrincipalSet,
+		Profile:      model.Profile,
 	}
 }
 
-func requestorFromJson(json RequestorJson) *RequestorModelV3 {
-	return &RequestorModelV3{
-		Type:   json.Type,
-		Groups: json.Groups,
-		Uid:    json.Uid,
-		Effect: json.Effect,
-		Agent:  agentFromJson(json.Agent),
-		User:   json.User,
+func requestorFromJson(json RequestorJson) *RequestorModelV5 {
+	return &RequestorModelV5{
+		Type:         json.Type,
+		Groups:       json.Groups,
+		Uid:          json.Uid,
+		Effect:       json.Effect,
+		Agent:        agentFromJson(json.Agent),
+		User:         json.User,
+		PrincipalSet: json.PrincipalSet,
+		Profile:      json.Profile,
 	}
 }
 
@@ -138,33 +157,122 @@ func getPath(name string) string {
 	return fmt.Sprintf("policy/name/%s", encodedName)
 }
 
-func toJson(model AccessPolicyModelV3) AccessPolicyJson {
+// RenameJson is the request body of the policy rename endpoint.
+type RenameJson struct {
+	Name string `json:"name"`
+}
+
+func getRenamePath(name string) string {
+	return fmt.Sprintf("%s/rename", getPath(name))
+}
+
+// listPolicies returns all of the organization's access policies, keyed by
+// name.
+func listPolicies(data *internal.P0ProviderData) (map[string]AccessPolicyJson, *http.Response, error) {
+	return listPoliciesWith(data.Get)
+}
+
+// listCachedPolicies is listPolicies, but lists the policies at most once per
+// plan (see GetCached). Use it only for advisory checks, and to find renamed
+// policies (see renamedPolicy).
+func listCachedPolicies(data *internal.P0ProviderData) (map[string]AccessPolicyJson, *http.Response, error) {
+	return listPoliciesWith(data.GetCached)
+}
+
+func listPoliciesWith(get func(path string, responseJson any) (*http.Response, error)) (map[string]AccessPolicyJson, *http.Response, error) {
+	var json AccessPoliciesJson
+	httpResponse, err := get("policy", &json)
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	existing := make(map[string]AccessPolicyJson, len(json.Policies))
+	for _, policy := range json.Policies {
+		if policy.Name != nil {
+			existing[*policy.Name] = policy
+		}
+	}
+	return existing, httpResponse, nil
+}
+
+// resolvePolicyName returns the name of the policy identified by importId,
+// which is either a policy name or a policy id. Names take precedence.
+func resolvePolicyName(importId string, existing map[string]AccessPolicyJson) (string, error) {
+	if _, ok := existing[importId]; ok {
+		return importId, nil
+	}
+	for _, name := range sortedNames(existing) {
+		if id := existing[name].Id; id != nil && *id == importId {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no access policy has the name or id %q", importId)
+}
+
+// renamedPolicy looks up, by id, the name that P0 currently stores a policy
+// under, for a policy that is no longer found under its name in state (for
+// example, because it was renamed outside of Terraform). It returns the
+// policy's current name if found; otherwise gone reports whether the policy
+// was deleted. A policy without an id in state is only found by name, so it
+// is gone. Neither is set if P0 lists policies without ids, since then a
+// renamed policy can't be told apart from a deleted one.
+func renamedPolicy(data *internal.P0ProviderData, id types.String) (name string, gone bool, err error) {
+	if id.IsNull() || id.IsUnknown() {
+		return "", true, nil
+	}
+	existing, httpResponse, err := listCachedPolicies(data)
+	if err != nil {
+		if httpResponse != nil && httpResponse.StatusCode == 404 {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	hasIds := false
+	for _, policy := range existing {
+		if policy.Id == nil {
+			continue
+		}
+		hasIds = true
+		if *policy.Id == id.ValueString() {
+			return *policy.Name, false, nil
+		}
+	}
+	return "", hasIds, nil
+}
+
+func toJson(model AccessPolicyModelV11) AccessPolicyJson {
 	return AccessPolicyJson{
-		Name:      model.Name,
-		Disabled:  model.Disabled,
-		Requestor: requestorToJson(model.Requestor),
-		Resource:  *model.Resource,
-		Approval:  model.Approval}
+		Name:          model.Name,
+		Disabled:      model.Disabled,
+		Requestor:     requestorToJson(model.Requestor),
+		Resource:      *model.Resource,
+		Approval:      model.Approval,
+		Schedule:      model.Schedule,
+		BreakGlass:    model.BreakGlass,
+		Notifications: model.Notifications,
+	}
 }
 
-func toModel(json AccessPolicyJson) AccessPolicyModelV3 {
-	return AccessPolicyModelV3{
-		Name:      json.Name,
-		Disabled:  json.Disabled,
-		Requestor: requestorFromJson(json.Requestor),
-		Resource:  &json.Resource,
-		Approval:  json.Approval,
+func toModel(json AccessPolicyJson) AccessPolicyModelV11 {
+	return AccessPolicyModelV11{
+		Id:             types.StringPointerValue(json.Id),
+		Name:           json.Name,
+		Disabled:       json.Disabled,
+		Requestor:      requestorFromJson(json.Requestor),
+		Resource:       &json.Resource,
+		Approval:       json.Approval,
+		Schedule:       json.Schedule,
+		BreakGlass:     json.BreakGlass,
+		Notifications:  json.Notifications,
+		LastModifiedBy: types.StringPointerValue(json.LastModifiedBy),
+		LastModifiedAt: types.StringPointerValue(json.LastModifiedAt),
 	}
 }
 
 func newAccessPolicySchema(version int64) schema.Schema {
 	attributes := map[string]schema.Attribute{
 		"name": schema.StringAttribute{
-			MarkdownDescription: "The name of the policy",
+			MarkdownDescription: "The name of the policy. Changing the name renames the policy in place.",
 			Required:            true,
-			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
-			},
 		},
 		"requestor": requestorAttribute(version),
 		"resource":  resourceAttribute,
@@ -179,11 +287,53 @@ func newAccessPolicySchema(version int64) schema.Schema {
 			Optional:            true,
 		}
 	}
+	// Likewise, the id attribute postdates schema version 2.
+	if version >= 3 {
+		attributes["id"] = schema.StringAttribute{
+			MarkdownDescription: "The policy's identifier in P0. Unlike `name`, this does not change when the policy is renamed.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+	// Likewise, the schedule attribute postdates schema version 4.
+	if version >= 5 {
+		attributes["schedule"] = scheduleAttribute()
+	}
+	// Likewise, the break_glass attribute postdates schema version 6.
+	if version >= 7 {
+		attributes["break_glass"] = breakGlassAttribute()
+	}
+	// Likewise, the notifications attribute postdates schema version 9.
+	if version >= 10 {
+		attributes["notifications"] = policyNotificationsAttribute()
+	}
+	// Likewise, the modification metadata postdates schema version 8. It
+	// changes on every update, so it isn't carried over from state.
+	if version >= 9 {
+		attributes["last_modified_by"] = schema.StringAttribute{
+			MarkdownDescription: "The email address of the user (or the identity of the API client) that last changed the policy.",
+			Computed:            true,
+		}
+		attributes["last_modified_at"] = schema.StringAttribute{
+			MarkdownDescription: "When the policy was last changed, as an RFC 3339 timestamp.",
+			Computed:            true,
+		}
+	}
 	return schema.Schema{
 		Version: version,
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: `An access policy that controls who can request access to what, and access requirements.
-See [the P0 access-policy docs](https://docs.p0.dev/just-in-time-access/request-routing).`,
+See [the P0 access-policy docs](https://docs.p0.dev/just-in-time-access/request-routing).
+
+When a policy is created or changed, the plan warns if it is shadowed by, contradicts, or duplicates one of the
+organization's existing policies. It is only compared with policies that already exist in P0: policies planned in the
+same run are not compared with each other, and later plans only compare policies that change. To compare a set of
+policies as planned, declare them in a single ` + "`p0_access_policies`" + ` resource.
+
+If the policy is changed outside of Terraform (e.g., disabled in the P0 app), refreshing it warns who changed it, and
+when.`,
 		Attributes: attributes,
 	}
 }
@@ -208,7 +358,7 @@ func (policy *AccessPolicy) Create(ctx context.Context, req resource.CreateReque
 	var diag = &resp.Diagnostics
 
 	// Load the plan into the model
-	var model AccessPolicyModelV3
+	var model AccessPolicyModelV11
 	diag.Append(req.Plan.Get(ctx, &model)...)
 	if diag.HasError() {
 		return
@@ -239,7 +389,7 @@ func (policy *AccessPolicy) Read(ctx context.Context, req resource.ReadRequest,
 	var diag = &resp.Diagnostics
 
 	// Load the state into the model
-	var model AccessPolicyModelV3
+	var model AccessPolicyModelV11
 	diag.Append(req.State.Get(ctx, &model)...)
 	if diag.HasError() {
 		return
@@ -248,20 +398,46 @@ func (policy *AccessPolicy) Read(ctx context.Context, req resource.ReadRequest,
 	// Read the access policy
 	var json AccessPolicyJson
 	httpResponse, httpErr := policy.data.Get(getPath(*model.Name), &json)
+	if httpErr != nil && (httpResponse == nil || httpResponse.StatusCode != 404) {
+		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to read access policy:\n%s", httpErr))
+		return
+	}
 	if httpErr != nil {
-		// Check if the error indicates that the resource was not found (404)
-		if httpResponse != nil && httpResponse.StatusCode == 404 {
+		// Not found under its name; it may have been renamed outside of Terraform.
+		name, gone, err := renamedPolicy(policy.data, model.Id)
+		if err != nil {
+			diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to list access policies:\n%s", err))
+			return
+		}
+		if gone {
 			tflog.Debug(ctx, "Access policy not found (404), removing from state")
-			// Remove the resource from state by calling RemoveResource.
 			resp.State.RemoveResource(ctx)
 			return
 		}
-
-		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to read access policy:\n%s", httpErr))
-		return
+		if name == "" {
+			diag.AddWarning(
+				"Access policy not found",
+				fmt.Sprintf("No access policy is named %q, and its id could not be looked up in the list of policies to find it under a new name. Keeping its last-known state; if it was deleted, run `terraform state rm`.", *model.Name),
+			)
+			return
+		}
+		httpResponse, httpErr = policy.data.Get(getPath(name), &json)
+		if httpErr != nil {
+			if httpResponse != nil && httpResponse.StatusCode == 404 {
+				tflog.Debug(ctx, "Access policy not found (404), removing from state")
+				resp.State.RemoveResource(ctx)
+				return
+			}
+			diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to read access policy:\n%s", httpErr))
+			return
+		}
 	}
 
-	model = toModel(json)
+	updated := toModel(json)
+	if summary, detail, ok := driftWarning(model, updated); ok {
+		diag.AddWarning(summary, detail)
+	}
+	model = updated
 
 	// Update the Terraform state to match the access policy returned by the API
 	diag.Append(resp.State.SetAttribute(ctx, path.Root("name"), model.Name)...)
@@ -274,7 +450,7 @@ func (policy *AccessPolicy) Update(ctx context.Context, req resource.UpdateReque
 	var diag = &resp.Diagnostics
 
 	// Load the plan into the model
-	var model AccessPolicyModelV3
+	var model AccessPolicyModelV11
 	diag.Append(req.Plan.Get(ctx, &model)...)
 	if diag.HasError() {
 		return
@@ -283,7 +459,7 @@ func (policy *AccessPolicy) Update(ctx context.Context, req resource.UpdateReque
 	tflog.Debug(ctx, fmt.Sprintf("Access policy to update: %+v", model))
 
 	// Read the current access policy from the Terraform state
-	var currentModel AccessPolicyModelV3
+	var currentModel AccessPolicyModelV11
 	diag.Append(req.State.Get(ctx, &currentModel)...)
 	if diag.HasError() {
 		return
@@ -291,6 +467,17 @@ func (policy *AccessPolicy) Update(ctx context.Context, req resource.UpdateReque
 
 	tflog.Debug(ctx, fmt.Sprintf("Current access policy state: %+v", currentModel))
 
+	// Rename the policy in place first, so that it's never absent
+	if currentModel.Name != nil && *currentModel.Name != *model.Name {
+		var renamedJson AccessPolicyJson
+		_, renameErr := policy.data.Post(getRenamePath(*currentModel.Name), &RenameJson{Name: *model.Name}, &renamedJson)
+		if renameErr != nil {
+			diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to rename access policy:\n%s", renameErr))
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Renamed access policy: %+v", renamedJson))
+	}
+
 	json := toJson(model)
 
 	// Update the access policy
@@ -298,6 +485,9 @@ func (policy *AccessPolicy) Update(ctx context.Context, req resource.UpdateReque
 	_, postErr := policy.data.Put(getPath(*model.Name), &json, &updatedJson)
 	if postErr != nil {
 		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to update access policy:\n%s", postErr))
+		// The policy may already have been renamed; record its new name, so the
+		// next plan doesn't try to rename it from a name that no longer exists.
+		diag.Append(resp.State.SetAttribute(ctx, path.Root("name"), model.Name)...)
 		return
 	}
 
@@ -314,21 +504,70 @@ func (policy *AccessPolicy) Delete(ctx context.Context, req resource.DeleteReque
 	var diag = &resp.Diagnostics
 
 	// Load the state into the model
-	var model AccessPolicyModelV3
+	var model AccessPolicyModelV11
 	diag.Append(req.State.Get(ctx, &model)...)
 	if diag.HasError() {
 		return
 	}
 
 	// Delete the access policy
-	_, postErr := policy.data.Delete(getPath(*model.Name))
-	if postErr != nil {
-		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to delete access policy:\n%s", postErr))
+	httpResponse, deleteErr := policy.data.Delete(getPath(*model.Name))
+	if deleteErr != nil && httpResponse != nil && httpResponse.StatusCode == 404 {
+		// Not found under its name; it may have been renamed outside of Terraform.
+		name, _, err := renamedPolicy(policy.data, model.Id)
+		if err != nil {
+			diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to list access policies:\n%s", err))
+			return
+		}
+		if name == "" {
+			tflog.Debug(ctx, "Access policy not found (404), nothing to delete")
+			return
+		}
+		_, deleteErr = policy.data.Delete(getPath(name))
+	}
+	if deleteErr != nil {
+		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to delete access policy:\n%s", deleteErr))
+	}
+}
+
+// ModifyPlan validates the policy's `resource` and notifications against the
+// organization's installed integrations, and warns about conflicts with its other policies.
+// Both need the API, so they can't be done in schema validators (which also
+// run during `terraform validate`, without a configured provider).
+func (policy *AccessPolicy) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate on destroy, or if the provider isn't configured yet.
+	if req.Plan.Raw.IsNull() || policy.data == nil {
+		return
 	}
+	policy.validateIntegrations(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	policy.analyzeConflicts(ctx, req, resp)
 }
 
+// ImportState imports a policy by name or id. Read then fills in the rest of
+// the policy, so `import` blocks can generate its configuration
+// (`terraform plan -generate-config-out`).
 func (policy *AccessPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	existing, httpResponse, err := listPolicies(policy.data)
+	if err != nil {
+		// Older P0 deployments don't list policies; fall back to importing by name.
+		if httpResponse != nil && httpResponse.StatusCode == 404 {
+			tflog.Debug(ctx, "Access policy list not available (404), importing by name")
+			resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+			return
+		}
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to list access policies:\n%s", err))
+		return
+	}
+
+	name, err := resolvePolicyName(req.ID, existing)
+	if err != nil {
+		resp.Diagnostics.AddError("Access policy not found", fmt.Sprintf("Unable to import access policy: %s.", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
 }
 
 func upgradeRequestorV0(prior *RequestorModelV0) RequestorModelV1 {
@@ -462,6 +701,7 @@ func upgradeRequestorV2(prior *RequestorModelV2) RequestorModelV3 {
 func upgradeModelV2(prior AccessPolicyModelV2) AccessPolicyModelV3 {
 	requestor := upgradeRequestorV2(prior.Requestor)
 	return AccessPolicyModelV3{
+		Id:        types.StringNull(),
 		Name:      prior.Name,
 		Disabled:  prior.Disabled,
 		Requestor: &requestor,
@@ -470,10 +710,242 @@ func upgradeModelV2(prior AccessPolicyModelV2) AccessPolicyModelV3 {
 	}
 }
 
+func upgradeApprovalV2(prior []ApprovalModelV2) []ApprovalModelV3 {
+	upgraded := make([]ApprovalModelV3, len(prior))
+	for i, approvalV2 := range prior {
+		var options *ApprovalOptionsModelV1
+		if approvalV2.Options != nil {
+			options = &ApprovalOptionsModelV1{
+				AllowOneParty:      approvalV2.Options.AllowOneParty,
+				BreakGlassApprover: approvalV2.Options.BreakGlassApprover,
+				RequirePreapproval: approvalV2.Options.RequirePreapproval,
+				RequireReason:      approvalV2.Options.RequireReason,
+				RequireDuration:    approvalV2.Options.RequireDuration,
+			}
+		}
+		upgraded[i] = ApprovalModelV3{
+			Directory:       approvalV2.Directory,
+			Integration:     approvalV2.Integration,
+			Groups:          approvalV2.Groups,
+			ProfileProperty: approvalV2.ProfileProperty,
+			Options:         options,
+			Services:        approvalV2.Services,
+			Type:            approvalV2.Type,
+			Effect:          approvalV2.Effect,
+		}
+	}
+	return upgraded
+}
+
+// upgradeModelV3 adds the (unset) per-rule request constraints.
+func upgradeModelV3(prior AccessPolicyModelV3) AccessPolicyModelV4 {
+	return AccessPolicyModelV4{
+		Id:        prior.Id,
+		Name:      prior.Name,
+		Disabled:  prior.Disabled,
+		Requestor: prior.Requestor,
+		Resource:  prior.Resource,
+		Approval:  upgradeApprovalV2(prior.Approval),
+	}
+}
+
+// upgradeModelV4 adds the (unset) schedule.
+func upgradeModelV4(prior AccessPolicyModelV4) AccessPolicyModelV5 {
+	return AccessPolicyModelV5{
+		Id:        prior.Id,
+		Name:      prior.Name,
+		Disabled:  prior.Disabled,
+		Requestor: prior.Requestor,
+		Resource:  prior.Resource,
+		Approval:  prior.Approval,
+	}
+}
+
+// upgradeApprovalV3 adds the (unset) approval stages and quorums.
+func upgradeApprovalV3(prior []ApprovalModelV3) []ApprovalModelV4 {
+	upgraded := make([]ApprovalModelV4, len(prior))
+	for i, approvalV3 := range prior {
+		var options *ApprovalOptionsModelV2
+		if approvalV3.Options != nil {
+			options = &ApprovalOptionsModelV2{
+				AllowOneParty:      approvalV3.Options.AllowOneParty,
+				BreakGlassApprover: approvalV3.Options.BreakGlassApprover,
+				RequirePreapproval: approvalV3.Options.RequirePreapproval,
+				RequireReason:      approvalV3.Options.RequireReason,
+				RequireDuration:    approvalV3.Options.RequireDuration,
+				MaxDuration:        approvalV3.Options.MaxDuration,
+				ReasonPattern:      approvalV3.Options.ReasonPattern,
+			}
+		}
+		upgraded[i] = ApprovalModelV4{
+			Directory:       approvalV3.Directory,
+			Integration:     approvalV3.Integration,
+			Groups:          approvalV3.Groups,
+			ProfileProperty: approvalV3.ProfileProperty,
+			Options:         options,
+			Services:        approvalV3.Services,
+			Type:            approvalV3.Type,
+			Effect:          approvalV3.Effect,
+		}
+	}
+	return upgraded
+}
+
+// upgradeModelV5 upgrades the approval rules to support stages and quorums.
+func upgradeModelV5(prior AccessPolicyModelV5) AccessPolicyModelV6 {
+	return AccessPolicyModelV6{
+		Id:        prior.Id,
+		Name:      prior.Name,
+		Disabled:  prior.Disabled,
+		Requestor: prior.Requestor,
+		Resource:  prior.Resource,
+		Approval:  upgradeApprovalV3(prior.Approval),
+		Schedule:  prior.Schedule,
+	}
+}
+
+// upgradeModelV6 adds the (unset) break-glass configuration.
+func upgradeModelV6(prior AccessPolicyModelV6) AccessPolicyModelV7 {
+	return AccessPolicyModelV7{
+		Id:        prior.Id,
+		Name:      prior.Name,
+		Disabled:  prior.Disabled,
+		Requestor: prior.Requestor,
+		Resource:  prior.Resource,
+		Approval:  prior.Approval,
+		Schedule:  prior.Schedule,
+	}
+}
+
+// upgradeRequestorV3 adds the (unset) principal set reference.
+func upgradeRequestorV3(prior *RequestorModelV3) *RequestorModelV4 {
+	if prior == nil {
+		return nil
+	}
+	return &RequestorModelV4{
+		Type:   prior.Type,
+		Groups: prior.Groups,
+		Uid:    prior.Uid,
+		Effect: prior.Effect,
+		Agent:  prior.Agent,
+		User:   prior.User,
+	}
+}
+
+// upgradeApprovalV4 adds the (unset) principal set reference.
+func upgradeApprovalV4(prior []ApprovalModelV4) []ApprovalModelV5 {
+	upgraded := make([]ApprovalModelV5, len(prior))
+	for i, approvalV4 := range prior {
+		upgraded[i] = ApprovalModelV5{
+			Directory:       approvalV4.Directory,
+			Integration:     approvalV4.Integration,
+			Groups:          approvalV4.Groups,
+			ProfileProperty: approvalV4.ProfileProperty,
+			Options:         approvalV4.Options,
+			Services:        approvalV4.Services,
+			Type:            approvalV4.Type,
+			Effect:          approvalV4.Effect,
+			Stage:           approvalV4.Stage,
+		}
+	}
+	return upgraded
+}
+
+// upgradeModelV7 upgrades the requestor and approval rules to support
+// principal sets.
+func upgradeModelV7(prior AccessPolicyModelV7) AccessPolicyModelV8 {
+	return AccessPolicyModelV8{
+		Id:         prior.Id,
+		Name:       prior.Name,
+		Disabled:   prior.Disabled,
+		Requestor:  upgradeRequestorV3(prior.Requestor),
+		Resource:   prior.Resource,
+		Approval:   upgradeApprovalV4(prior.Approval),
+		Schedule:   prior.Schedule,
+		BreakGlass: prior.BreakGlass,
+	}
+}
+
+// upgradeModelV8 adds the (unset) modification metadata, which the next
+// refresh reads from P0.
+func upgradeModelV8(prior AccessPolicyModelV8) AccessPolicyModelV9 {
+	return AccessPolicyModelV9{
+		Id:             prior.Id,
+		Name:           prior.Name,
+		Disabled:       prior.Disabled,
+		Requestor:      prior.Requestor,
+		Resource:       prior.Resource,
+		Approval:       prior.Approval,
+		Schedule:       prior.Schedule,
+		BreakGlass:     prior.BreakGlass,
+		LastModifiedBy: types.StringNull(),
+		LastModifiedAt: types.StringNull(),
+	}
+}
+
+// upgradeModelV9 adds the (unset) notifications.
+func upgradeModelV9(prior AccessPolicyModelV9) AccessPolicyModelV10 {
+	return AccessPolicyModelV10{
+		Id:             prior.Id,
+		Name:           prior.Name,
+		Disabled:       prior.Disabled,
+		Requestor:      prior.Requestor,
+		Resource:       prior.Resource,
+		Approval:       prior.Approval,
+		Schedule:       prior.Schedule,
+		BreakGlass:     prior.BreakGlass,
+		LastModifiedBy: prior.LastModifiedBy,
+		LastModifiedAt: prior.LastModifiedAt,
+	}
+}
+
+// upgradeRequestorV4 adds the (unset) profile conditions.
+func upgradeRequestorV4(prior *RequestorModelV4) *RequestorModelV5 {
+	if prior == nil {
+		return nil
+	}
+	return &RequestorModelV5{
+		Type:         prior.Type,
+		Groups:       prior.Groups,
+		Uid:          prior.Uid,
+		Effect:       prior.Effect,
+		Agent:        prior.Agent,
+		User:         prior.User,
+		PrincipalSet: prior.PrincipalSet,
+	}
+}
+
+// upgradeModelV10 adds the (unset) requestor profile conditions.
+func upgradeModelV10(prior AccessPolicyModelV10) AccessPolicyModelV11 {
+	return AccessPolicyModelV11{
+		Id:             prior.Id,
+		Name:           prior.Name,
+		Disabled:       prior.Disabled,
+		Requestor:      upgradeRequestorV4(prior.Requestor),
+		Resource:       prior.Resource,
+		Approval:       prior.Approval,
+		Schedule:       prior.Schedule,
+		BreakGlass:     prior.BreakGlass,
+		Notifications:  prior.Notifications,
+		LastModifiedBy: prior.LastModifiedBy,
+		LastModifiedAt: prior.LastModifiedAt,
+	}
+}
+
+// UpgradeState upgrades prior states directly to the current schema version,
+// as the framework requires, by chaining the per-version upgrades.
 func (policy *AccessPolicy) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
 	var schemaV0 = newAccessPolicySchema(0)
 	var schemaV1 = newAccessPolicySchema(1)
 	var schemaV2 = newAccessPolicySchema(2)
+	var schemaV3 = newAccessPolicySchema(3)
+	var schemaV4 = newAccessPolicySchema(4)
+	var schemaV5 = newAccessPolicySchema(5)
+	var schemaV6 = newAccessPolicySchema(6)
+	var schemaV7 = newAccessPolicySchema(7)
+	var schemaV8 = newAccessPolicySchema(8)
+	var schemaV9 = newAccessPolicySchema(9)
+	var schemaV10 = newAccessPolicySchema(10)
 	return map[int64]resource.StateUpgrader{
 		0: {
 			PriorSchema: &schemaV0,
@@ -483,7 +955,7 @@ func (policy *AccessPolicy) UpgradeState(ctx context.Context) map[int64]resource
 				if resp.Diagnostics.HasError() {
 					return
 				}
-				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV0(prior))...)
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(upgradeModelV3(upgradeModelV2(upgradeModelV1(upgradeModelV0(prior))))))))))))...)
 			},
 		},
 		1: {
@@ -494,7 +966,7 @@ func (policy *AccessPolicy) UpgradeState(ctx context.Context) map[int64]resource
 				if resp.Diagnostics.HasError() {
 					return
 				}
-				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV1(prior))...)
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(upgradeModelV3(upgradeModelV2(upgradeModelV1(prior)))))))))))...)
 			},
 		},
 		2: {
@@ -505,7 +977,95 @@ func (policy *AccessPolicy) UpgradeState(ctx context.Context) map[int64]resource
 				if resp.Diagnostics.HasError() {
 					return
 				}
-				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV2(prior))...)
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(upgradeModelV3(upgradeModelV2(prior))))))))))...)
+			},
+		},
+		3: {
+			PriorSchema: &schemaV3,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccessPolicyModelV3
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(upgradeModelV3(prior)))))))))...)
+			},
+		},
+		4: {
+			PriorSchema: &schemaV4,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccessPolicyModelV4
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(prior))))))))...)
+			},
+		},
+		5: {
+			PriorSchema: &schemaV5,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccessPolicyModelV5
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(prior)))))))...)
+			},
+		},
+		6: {
+			PriorSchema: &schemaV6,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccessPolicyModelV6
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(prior))))))...)
+			},
+		},
+		7: {
+			PriorSchema: &schemaV7,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccessPolicyModelV7
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(prior)))))...)
+			},
+		},
+		8: {
+			PriorSchema: &schemaV8,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccessPolicyModelV8
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(prior))))...)
+			},
+		},
+		9: {
+			PriorSchema: &schemaV9,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccessPolicyModelV9
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(upgradeModelV9(prior)))...)
+			},
+		},
+		10: {
+			PriorSchema: &schemaV10,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccessPolicyModelV10
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeModelV10(prior))...)
 			},
 		},
 	}
@@ -528,6 +1088,14 @@ func (policy *AccessPolicy) MoveState(ctx context.Context) []resource.StateMover
 	var schemaV1 = newAccessPolicySchema(1)
 	var schemaV2 = newAccessPolicySchema(2)
 	var schemaV3 = newAccessPolicySchema(3)
+	var schemaV4 = newAccessPolicySchema(4)
+	var schemaV5 = newAccessPolicySchema(5)
+	var schemaV6 = newAccessPolicySchema(6)
+	var schemaV7 = newAccessPolicySchema(7)
+	var schemaV8 = newAccessPolicySchema(8)
+	var schemaV9 = newAccessPolicySchema(9)
+	var schemaV10 = newAccessPolicySchema(10)
+	var schemaV11 = newAccessPolicySchema(11)
 	return []resource.StateMover{
 		{
 			SourceSchema: &schemaV0,
@@ -540,7 +1108,7 @@ func (policy *AccessPolicy) MoveState(ctx context.Context) []resource.StateMover
 				if resp.Diagnostics.HasError() {
 					return
 				}
-				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV2(upgradeModelV1(upgradeModelV0(prior))))...)
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(upgradeModelV3(upgradeModelV2(upgradeModelV1(upgradeModelV0(prior))))))))))))...)
 			},
 		},
 		{
@@ -554,7 +1122,7 @@ func (policy *AccessPolicy) MoveState(ctx context.Context) []resource.StateMover
 				if resp.Diagnostics.HasError() {
 					return
 				}
-				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV2(upgradeModelV1(prior)))...)
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(upgradeModelV3(upgradeModelV2(upgradeModelV1(prior)))))))))))...)
 			},
 		},
 		{
@@ -568,7 +1136,7 @@ func (policy *AccessPolicy) MoveState(ctx context.Context) []resource.StateMover
 				if resp.Diagnostics.HasError() {
 					return
 				}
-				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV2(prior))...)
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(upgradeModelV3(upgradeModelV2(prior))))))))))...)
 			},
 		},
 		{
@@ -582,6 +1150,118 @@ func (policy *AccessPolicy) MoveState(ctx context.Context) []resource.StateMover
 				if resp.Diagnostics.HasError() {
 					return
 				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(upgradeModelV3(prior)))))))))...)
+			},
+		},
+		{
+			SourceSchema: &schemaV4,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !isRoutingRuleMoveRequest(req, 4) || req.SourceState == nil {
+					return
+				}
+				var prior AccessPolicyModelV4
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(upgradeModelV4(prior))))))))...)
+			},
+		},
+		{
+			SourceSchema: &schemaV5,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !isRoutingRuleMoveRequest(req, 5) || req.SourceState == nil {
+					return
+				}
+				var prior AccessPolicyModelV5
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(upgradeModelV5(prior)))))))...)
+			},
+		},
+		{
+			SourceSchema: &schemaV6,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !isRoutingRuleMoveRequest(req, 6) || req.SourceState == nil {
+					return
+				}
+				var prior AccessPolicyModelV6
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(upgradeModelV6(prior))))))...)
+			},
+		},
+		{
+			SourceSchema: &schemaV7,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !isRoutingRuleMoveRequest(req, 7) || req.SourceState == nil {
+					return
+				}
+				var prior AccessPolicyModelV7
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(upgradeModelV7(prior)))))...)
+			},
+		},
+		{
+			SourceSchema: &schemaV8,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !isRoutingRuleMoveRequest(req, 8) || req.SourceState == nil {
+					return
+				}
+				var prior AccessPolicyModelV8
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(upgradeModelV8(prior))))...)
+			},
+		},
+		{
+			SourceSchema: &schemaV9,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !isRoutingRuleMoveRequest(req, 9) || req.SourceState == nil {
+					return
+				}
+				var prior AccessPolicyModelV9
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(upgradeModelV9(prior)))...)
+			},
+		},
+		{
+			SourceSchema: &schemaV10,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !isRoutingRuleMoveRequest(req, 10) || req.SourceState == nil {
+					return
+				}
+				var prior AccessPolicyModelV10
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeModelV10(prior))...)
+			},
+		},
+		{
+			SourceSchema: &schemaV11,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !isRoutingRuleMoveRequest(req, 11) || req.SourceState == nil {
+					return
+				}
+				var prior AccessPolicyModelV11
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
 				resp.Diagnostics.Append(resp.TargetState.Set(ctx, prior)...)
 			},
 		},
@@ -4,8 +4,21 @@
 package accesspolicy
 
 import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"slices"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/p0-security/terraform-provider-p0/internal"
 )
 
 // TestAgentToJsonOwnerGroup verifies the "owner-group" variant's flat
@@ -141,6 +154,418 @@ func TestUpgradeModelV2(t *testing.T) {
 	if got.Requestor.Type != "any" || got.Requestor.Agent != nil || got.Requestor.User != nil {
 		t.Errorf("Requestor = %+v; want upgraded passthrough with nil Agent/User", got.Requestor)
 	}
+	if !got.Id.IsNull() {
+		t.Errorf("Id = %v; want null until the policy is next read", got.Id)
+	}
+}
+
+// TestUpgradeModelV3 verifies the V3->V4 access-policy upgrade carries over
+// approval options and leaves the new per-rule constraints unset.
+func TestUpgradeModelV3(t *testing.T) {
+	name := "test-policy"
+	requireReason := true
+	prior := AccessPolicyModelV3{
+		Id:        types.StringValue("policy-id"),
+		Name:      &name,
+		Requestor: &RequestorModelV3{Type: "any"},
+		Resource:  &ResourceModel{Type: "any"},
+		Approval: []ApprovalModelV2{
+			{Type: "auto", Integration: strPtr("pagerduty"), Options: &ApprovalOptionsModel{RequireReason: &requireReason}},
+			{Type: "deny"},
+		},
+	}
+
+	got := upgradeModelV3(prior)
+
+	if got.Id != prior.Id || got.Name != prior.Name || got.Requestor != prior.Requestor || got.Resource != prior.Resource {
+		t.Errorf("upgradeModelV3 changed a passthrough field: got %+v, prior %+v", got, prior)
+	}
+	if len(got.Approval) != 2 || got.Approval[0].Type != "auto" || got.Approval[0].Integration != prior.Approval[0].Integration || got.Approval[1].Options != nil {
+		t.Fatalf("Approval = %+v; want %+v", got.Approval, prior.Approval)
+	}
+	options := got.Approval[0].Options
+	if options.RequireReason != &requireReason {
+		t.Errorf("Options.RequireReason = %v; want prior value", options.RequireReason)
+	}
+	if options.MaxDuration != nil || options.ReasonPattern != nil {
+		t.Errorf("Options = %+v; want no max duration or reason pattern", options)
+	}
+}
+
+// TestUpgradeModelV4 verifies the V4->V5 access-policy upgrade passes every
+// field through and leaves the schedule unset.
+func TestUpgradeModelV4(t *testing.T) {
+	prior := AccessPolicyModelV4{
+		Id:        types.StringValue("policy-id"),
+		Name:      strPtr("test-policy"),
+		Requestor: &RequestorModelV3{Type: "any"},
+		Resource:  &ResourceModel{Type: "any"},
+		Approval:  []ApprovalModelV3{{Type: "deny"}},
+	}
+
+	got := upgradeModelV4(prior)
+
+	if got.Id != prior.Id || got.Name != prior.Name || got.Requestor != prior.Requestor || got.Resource != prior.Resource || !reflect.DeepEqual(got.Approval, prior.Approval) {
+		t.Errorf("upgradeModelV4 changed a passthrough field: got %+v, prior %+v", got, prior)
+	}
+	if got.Schedule != nil {
+		t.Errorf("Schedule = %+v; want nil", got.Schedule)
+	}
+}
+
+// TestUpgradeModelV5 verifies the V5->V6 access-policy upgrade carries over
+// approval options and leaves stages and quorums unset.
+func TestUpgradeModelV5(t *testing.T) {
+	reasonPattern := "^[A-Z]+-[0-9]+"
+	prior := AccessPolicyModelV5{
+		Id:        types.StringValue("policy-id"),
+		Name:      strPtr("test-policy"),
+		Requestor: &RequestorModelV3{Type: "any"},
+		Resource:  &ResourceModel{Type: "any"},
+		Approval:  []ApprovalModelV3{{Type: "p0", Options: &ApprovalOptionsModelV1{ReasonPattern: &reasonPattern}}},
+		Schedule:  &ScheduleModel{Timezone: "UTC", Days: []string{"monday"}},
+	}
+
+	got := upgradeModelV5(prior)
+
+	if got.Id != prior.Id || got.Name != prior.Name || got.Requestor != prior.Requestor || got.Resource != prior.Resource || got.Schedule != prior.Schedule {
+		t.Errorf("upgradeModelV5 changed a passthrough field: got %+v, prior %+v", got, prior)
+	}
+	if len(got.Approval) != 1 || got.Approval[0].Type != "p0" || got.Approval[0].Stage != nil {
+		t.Fatalf("Approval = %+v; want the prior rule without a stage", got.Approval)
+	}
+	if options := got.Approval[0].Options; options.ReasonPattern != &reasonPattern || options.MinApprovers != nil {
+		t.Errorf("Options = %+v; want the prior reason pattern and no quorum", options)
+	}
+}
+
+// TestUpgradeModelV6 verifies the V6->V7 access-policy upgrade passes every
+// field through and leaves the break-glass configuration unset.
+func TestUpgradeModelV6(t *testing.T) {
+	prior := AccessPolicyModelV6{
+		Id:        types.StringValue("policy-id"),
+		Name:      strPtr("test-policy"),
+		Requestor: &RequestorModelV3{Type: "any"},
+		Resource:  &ResourceModel{Type: "any"},
+		Approval:  []ApprovalModelV4{{Type: "p0", Stage: ptr(int64(1))}},
+		Schedule:  &ScheduleModel{Timezone: "UTC", Days: []string{"monday"}},
+	}
+
+	got := upgradeModelV6(prior)
+
+	if got.Id != prior.Id || got.Name != prior.Name || got.Requestor != prior.Requestor || got.Resource != prior.Resource || got.Schedule != prior.Schedule || !reflect.DeepEqual(got.Approval, prior.Approval) {
+		t.Errorf("upgradeModelV6 changed a passthrough field: got %+v, prior %+v", got, prior)
+	}
+	if got.BreakGlass != nil {
+		t.Errorf("BreakGlass = %+v; want nil", got.BreakGlass)
+	}
+}
+
+func TestUpgradeModelV7(t *testing.T) {
+	prior := AccessPolicyModelV7{
+		Id:         types.StringValue("policy-id"),
+		Name:       strPtr("test-policy"),
+		Requestor:  &RequestorModelV3{Type: "user", Uid: strPtr("alice@example.com")},
+		Resource:   &ResourceModel{Type: "any"},
+		Approval:   []ApprovalModelV4{{Type: "p0", Stage: ptr(int64(1)), Options: &ApprovalOptionsModelV2{MinApprovers: ptr(int64(2))}}},
+		BreakGlass: &BreakGlassModel{RequireReview: ptr(true)},
+	}
+
+	got := upgradeModelV7(prior)
+
+	if got.Id != prior.Id || got.Name != prior.Name || got.Resource != prior.Resource || got.BreakGlass != prior.BreakGlass {
+		t.Errorf("upgradeModelV7 changed a passthrough field: got %+v, prior %+v", got, prior)
+	}
+	wantRequestor := &RequestorModelV4{Type: "user", Uid: strPtr("alice@example.com")}
+	if !reflect.DeepEqual(got.Requestor, wantRequestor) {
+		t.Errorf("Requestor = %+v; want %+v", got.Requestor, wantRequestor)
+	}
+	wantApproval := []ApprovalModelV5{{Type: "p0", Stage: ptr(int64(1)), Options: &ApprovalOptionsModelV2{MinApprovers: ptr(int64(2))}}}
+	if !reflect.DeepEqual(got.Approval, wantApproval) {
+		t.Errorf("Approval = %+v; want %+v", got.Approval, wantApproval)
+	}
+}
+
+func TestUpgradeModelV8(t *testing.T) {
+	prior := AccessPolicyModelV8{
+		Id:        types.StringValue("policy-id"),
+		Name:      strPtr("test-policy"),
+		Disabled:  ptr(true),
+		Requestor: &RequestorModelV4{Type: "principal-set", PrincipalSet: strPtr("oncall")},
+		Resource:  &ResourceModel{Type: "any"},
+		Approval:  []ApprovalModelV5{{Type: "principal-set", PrincipalSet: strPtr("oncall")}},
+	}
+
+	got := upgradeModelV8(prior)
+
+	if got.Id != prior.Id || got.Name != prior.Name || got.Disabled != prior.Disabled || got.Requestor != prior.Requestor || got.Resource != prior.Resource || !reflect.DeepEqual(got.Approval, prior.Approval) {
+		t.Errorf("upgradeModelV8 changed a passthrough field: got %+v, prior %+v", got, prior)
+	}
+	if !got.LastModifiedBy.IsNull() || !got.LastModifiedAt.IsNull() {
+		t.Errorf("LastModifiedBy, LastModifiedAt = %v, %v; want null", got.LastModifiedBy, got.LastModifiedAt)
+	}
+}
+
+func TestUpgradeModelV9(t *testing.T) {
+	prior := AccessPolicyModelV9{
+		Id:             types.StringValue("policy-id"),
+		Name:           strPtr("test-policy"),
+		Requestor:      &RequestorModelV4{Type: "any"},
+		Resource:       &ResourceModel{Type: "any"},
+		BreakGlass:     &BreakGlassModel{Notifications: []NotificationModel{{Integration: "slack", Target: "C123"}}},
+		LastModifiedBy: types.StringValue("alice@example.com"),
+		LastModifiedAt: types.StringValue("2025-01-01T00:00:00Z"),
+	}
+
+	got := upgradeModelV9(prior)
+
+	if got.Id != prior.Id || got.Name != prior.Name || got.Requestor != prior.Requestor || got.BreakGlass != prior.BreakGlass || got.LastModifiedBy != prior.LastModifiedBy || got.LastModifiedAt != prior.LastModifiedAt {
+		t.Errorf("upgradeModelV9 changed a passthrough field: got %+v, prior %+v", got, prior)
+	}
+	if got.Notifications != nil {
+		t.Errorf("Notifications = %v; want nil", got.Notifications)
+	}
+}
+
+func TestUpgradeModelV10(t *testing.T) {
+	prior := AccessPolicyModelV10{
+		Id:             types.StringValue("policy-id"),
+		Name:           strPtr("test-policy"),
+		Requestor:      &RequestorModelV4{Type: "principal-set", PrincipalSet: strPtr("oncall")},
+		Resource:       &ResourceModel{Type: "any"},
+		Approval:       []ApprovalModelV5{{Type: "p0"}},
+		Notifications:  []NotificationModel{{Integration: "slack", Target: "C123"}},
+		LastModifiedBy: types.StringValue("alice@example.com"),
+		LastModifiedAt: types.StringValue("2025-01-01T00:00:00Z"),
+	}
+
+	got := upgradeModelV10(prior)
+
+	want := &RequestorModelV5{Type: "principal-set", PrincipalSet: prior.Requestor.PrincipalSet}
+	if !reflect.DeepEqual(got.Requestor, want) {
+		t.Errorf("Requestor = %+v; want %+v", got.Requestor, want)
+	}
+	if got.Id != prior.Id || got.Name != prior.Name || got.Resource != prior.Resource || !reflect.DeepEqual(got.Approval, prior.Approval) ||
+		!reflect.DeepEqual(got.Notifications, prior.Notifications) || got.LastModifiedBy != prior.LastModifiedBy || got.LastModifiedAt != prior.LastModifiedAt {
+		t.Errorf("upgradeModelV10 changed a passthrough field: got %+v, prior %+v", got, prior)
+	}
+	if upgradeRequestorV4(nil) != nil {
+		t.Error("upgradeRequestorV4(nil) != nil")
+	}
+}
+
+// Prior schemas must decode states written at their version, so each must
+// have exactly the requestor attributes of its model.
+func TestPriorSchemaRequestorAttributes(t *testing.T) {
+	cases := map[int64][]string{
+		2:  {"effect", "groups", "type", "uid"},
+		3:  {"agent", "effect", "groups", "type", "uid", "user"},
+		7:  {"agent", "effect", "groups", "type", "uid", "user"},
+		8:  {"agent", "effect", "groups", "principal_set", "type", "uid", "user"},
+		10: {"agent", "effect", "groups", "principal_set", "type", "uid", "user"},
+		11: {"agent", "effect", "groups", "principal_set", "profile", "type", "uid", "user"},
+	}
+	for version, want := range cases {
+		requestor := newAccessPolicySchema(version).Attributes["requestor"].(schema.SingleNestedAttribute)
+		got := slices.Sorted(maps.Keys(requestor.Attributes))
+		if !slices.Equal(got, want) {
+			t.Errorf("version %d requestor attributes = %v; want %v", version, got, want)
+		}
+	}
+}
+
+func TestResolvePolicyName(t *testing.T) {
+	existing := map[string]AccessPolicyJson{
+		"eng":         {Id: strPtr("pol_1"), Name: strPtr("eng")},
+		"ops":         {Id: strPtr("pol_2"), Name: strPtr("ops")},
+		"pol_3":       {Id: strPtr("pol_4"), Name: strPtr("pol_3")},
+		"legacy-rule": {Name: strPtr("legacy-rule")},
+	}
+	cases := map[string]string{
+		"eng":         "eng",
+		"pol_2":       "ops",
+		"pol_3":       "pol_3", // names take precedence over ids
+		"legacy-rule": "legacy-rule",
+	}
+	for importId, want := range cases {
+		if got, err := resolvePolicyName(importId, existing); err != nil || got != want {
+			t.Errorf("resolvePolicyName(%q) = %q, %v; want %q", importId, got, err, want)
+		}
+	}
+	if _, err := resolvePolicyName("missing", existing); err == nil {
+		t.Error("resolvePolicyName(\"missing\") succeeded; want an error")
+	}
 }
 
 func strPtr(s string) *string { return &s }
+
+// policyApi serves canned responses keyed by "<method> <path>", recording the
+// requests it receives. A missing response is a 404, an empty one is a 400,
+// and a DELETE with a response succeeds with no content.
+type policyApi struct {
+	responses map[string]string
+	requests  []string
+}
+
+func (api *policyApi) start(t *testing.T) *AccessPolicy {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		api.requests = append(api.requests, key)
+		body, ok := api.responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		if body == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			body = `{"error": "bad request"}`
+		} else if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return &AccessPolicy{data: &internal.P0ProviderData{BaseUrl: server.URL, Authentication: "Bearer x", Client: server.Client()}}
+}
+
+func policyModel(id string, name string) AccessPolicyModelV11 {
+	return AccessPolicyModelV11{
+		Id:             types.StringValue(id),
+		Name:           strPtr(name),
+		Requestor:      &RequestorModelV5{Type: "any"},
+		Resource:       &ResourceModel{Type: "any"},
+		Approval:       []ApprovalModelV5{},
+		LastModifiedBy: types.StringNull(),
+		LastModifiedAt: types.StringNull(),
+	}
+}
+
+func policyState(t *testing.T, model AccessPolicyModelV11) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	policySchema := newAccessPolicySchema(currentSchemaVersion)
+	state := tfsdk.State{Schema: policySchema, Raw: tftypes.NewValue(policySchema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("state.Set: %v", diags)
+	}
+	return state
+}
+
+func stateName(t *testing.T, state tfsdk.State) string {
+	t.Helper()
+	var name types.String
+	if diags := state.GetAttribute(context.Background(), path.Root("name"), &name); diags.HasError() {
+		t.Fatalf("state.GetAttribute: %v", diags)
+	}
+	return name.ValueString()
+}
+
+const renamedPolicyJson = `{"id": "pol_1", "name": "new", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}`
+
+// TestUpdateRename verifies a renamed policy is renamed in place before it is
+// updated, and that its new name is kept in state even if the update fails.
+func TestUpdateRename(t *testing.T) {
+	for _, putFails := range []bool{false, true} {
+		api := &policyApi{responses: map[string]string{
+			"POST /policy/name/old/rename": renamedPolicyJson,
+			"PUT /policy/name/new":         renamedPolicyJson,
+		}}
+		if putFails {
+			api.responses["PUT /policy/name/new"] = ""
+		}
+		policy := api.start(t)
+
+		prior := policyState(t, policyModel("pol_1", "old"))
+		planned := policyState(t, policyModel("pol_1", "new"))
+		resp := &resource.UpdateResponse{State: prior}
+		policy.Update(context.Background(), resource.UpdateRequest{Plan: tfsdk.Plan(planned), State: prior}, resp)
+
+		if want := []string{"POST /policy/name/old/rename", "PUT /policy/name/new"}; !slices.Equal(api.requests, want) {
+			t.Errorf("requests = %v; want %v", api.requests, want)
+		}
+		if resp.Diagnostics.HasError() != putFails {
+			t.Errorf("putFails = %v: diagnostics = %v", putFails, resp.Diagnostics)
+		}
+		if got := stateName(t, resp.State); got != "new" {
+			t.Errorf("putFails = %v: state name = %q; want \"new\"", putFails, got)
+		}
+	}
+}
+
+// TestReadById verifies that a policy is read by name, that a policy renamed
+// outside of Terraform is found by id under its new name, and that a policy is
+// only removed from state once the list of policies shows its id is gone.
+func TestReadById(t *testing.T) {
+	ctx := context.Background()
+	read := func(t *testing.T, policies string, model AccessPolicyModelV11) (*policyApi, *resource.ReadResponse) {
+		t.Helper()
+		api := &policyApi{responses: map[string]string{
+			"GET /policy":          `{"policies": [` + policies + `]}`,
+			"GET /policy/name/eng": `{"id": "pol_3", "name": "eng", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}`,
+			"GET /policy/name/new": renamedPolicyJson,
+		}}
+		state := policyState(t, model)
+		resp := &resource.ReadResponse{State: state}
+		api.start(t).Read(ctx, resource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Read: %v", resp.Diagnostics)
+		}
+		return api, resp
+	}
+
+	t.Run("by name", func(t *testing.T) {
+		api, resp := read(t, renamedPolicyJson, policyModel("pol_3", "eng"))
+		if got := stateName(t, resp.State); got != "eng" {
+			t.Errorf("state name = %q; want \"eng\"", got)
+		}
+		if want := []string{"GET /policy/name/eng"}; !slices.Equal(api.requests, want) {
+			t.Errorf("requests = %v; want %v", api.requests, want)
+		}
+	})
+
+	t.Run("renamed", func(t *testing.T) {
+		_, resp := read(t, renamedPolicyJson, policyModel("pol_1", "old"))
+		if got := stateName(t, resp.State); got != "new" {
+			t.Errorf("state name = %q; want \"new\"", got)
+		}
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		_, resp := read(t, renamedPolicyJson, policyModel("pol_2", "old"))
+		if !resp.State.Raw.IsNull() {
+			t.Errorf("state = %v; want it removed", resp.State.Raw)
+		}
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		_, resp := read(t, `{"name": "new", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}`, policyModel("pol_2", "old"))
+		if resp.State.Raw.IsNull() {
+			t.Error("state removed; want it kept when the list has no ids")
+		}
+		if len(resp.Diagnostics.Warnings()) != 1 {
+			t.Errorf("diagnostics = %v; want a warning", resp.Diagnostics)
+		}
+	})
+}
+
+// TestDeleteRenamed verifies that Delete deletes a policy renamed outside of
+// Terraform under its new name.
+func TestDeleteRenamed(t *testing.T) {
+	api := &policyApi{responses: map[string]string{
+		"GET /policy":             `{"policies": [` + renamedPolicyJson + `]}`,
+		"DELETE /policy/name/new": `{}`,
+	}}
+	state := policyState(t, policyModel("pol_1", "old"))
+	resp := &resource.DeleteResponse{State: state}
+	api.start(t).Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", resp.Diagnostics)
+	}
+	want := []string{"DELETE /policy/name/old", "GET /policy", "DELETE /policy/name/new"}
+	if !slices.Equal(api.requests, want) {
+		t.Errorf("requests = %v; want %v", api.requests, want)
+	}
+}
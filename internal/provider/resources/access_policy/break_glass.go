@@ -0,0 +1,112 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p0-security/terraform-provider-p0/internal/provider/resources/settings"
+)
+
+// BreakGlassModel configures a policy as a break-glass (emergency access)
+// route.
+type BreakGlassModel struct {
+	MaxDuration   *DurationModel      `json:"maxDuration,omitempty" tfsdk:"max_duration"`
+	Notifications []NotificationModel `json:"notifications,omitempty" tfsdk:"notifications"`
+	RequireReview *bool               `json:"requireReview,omitempty" tfsdk:"require_review"`
+	ReviewWithin  *DurationModel      `json:"reviewWithin,omitempty" tfsdk:"review_within"`
+}
+
+// breakGlassAttribute builds the `break_glass` schema, which only exists at
+// schema version 7 and later.
+func breakGlassAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: `If present, this policy is a break-glass route: its requestors may request emergency access, which may be
+granted by any break-glass approver (an 'approval' rule with ` + "`options.break_glass_approver = true`" + `). At least one
+approval rule must be a break-glass approver.`,
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"max_duration": schema.SingleNestedAttribute{
+				MarkdownDescription: `The longest duration for which emergency access may be granted. Use ` + "`provider::p0::duration`" + ` to write it as a
+string, e.g. ` + "`max_duration = provider::p0::duration(\"1h\")`" + `.`,
+				Optional:   true,
+				Attributes: settings.DurationAttributes(),
+			},
+			"notifications": notificationsAttribute("Where P0 announces emergency access requests and grants, in addition to the usual approver notifications."),
+			"require_review": schema.BoolAttribute{
+				MarkdownDescription: "If true, each emergency access grant must be reviewed after the fact by a break-glass approver.",
+				Optional:            true,
+			},
+			"review_within": schema.SingleNestedAttribute{
+				MarkdownDescription: "May only be used if 'require_review' is true. How soon after the grant the review is due; if omitted, P0's default applies.",
+				Optional:            true,
+				Attributes:          settings.DurationAttributes(),
+			},
+		},
+		Validators: []validator.Object{BreakGlassConstraints()},
+	}
+}
+
+// isBreakGlassApprover reports whether an approval rule may grant break-glass
+// requests. The option doesn't apply to automated rules.
+func isBreakGlassApprover(rule ApprovalModelV5) bool {
+	return rule.Type != "auto" && rule.Type != "deny" &&
+		rule.Options != nil && rule.Options.BreakGlassApprover != nil && *rule.Options.BreakGlassApprover
+}
+
+// breakGlassConstraints validates a `break_glass` object: its policy must
+// have a break-glass approver, and `review_within` requires `require_review`.
+type breakGlassConstraints struct{}
+
+// BreakGlassConstraints returns an object validator for a policy's
+// `break_glass`, which checks the sibling `approval` list.
+func BreakGlassConstraints() validator.Object {
+	return breakGlassConstraints{}
+}
+
+func (v breakGlassConstraints) Description(_ context.Context) string {
+	return "the policy must have a break-glass approver, and `review_within` requires `require_review`"
+}
+
+func (v breakGlassConstraints) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v breakGlassConstraints) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	requireReview, _ := attributes["require_review"].(types.Bool)
+	if reviewWithin, ok := attributes["review_within"]; ok && !reviewWithin.IsNull() && !requireReview.IsUnknown() && !requireReview.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("review_within"),
+			"Attribute not allowed",
+			"`review_within` may only be used when `require_review` is true.",
+		)
+	}
+
+	var approval ApprovalsValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("approval"), &approval)...)
+	if resp.Diagnostics.HasError() || approval.IsNull() || approval.IsUnknown() {
+		return
+	}
+	var rules []ApprovalModelV5
+	// Rules with unknown values can't be checked until apply.
+	if diags := approval.ElementsAs(ctx, &rules, false); diags.HasError() {
+		return
+	}
+	if !slices.ContainsFunc(rules, isBreakGlassApprover) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Missing break-glass approver",
+			"A break-glass policy must have at least one 'approval' rule, other than an 'auto' or 'deny' rule, with `options.break_glass_approver = true`.",
+		)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import "testing"
+
+func TestIsBreakGlassApprover(t *testing.T) {
+	breakGlass := &ApprovalOptionsModelV2{BreakGlassApprover: ptr(true)}
+	cases := []struct {
+		rule ApprovalModelV5
+		want bool
+	}{
+		{rule: ApprovalModelV5{Type: "p0", Options: breakGlass}, want: true},
+		{rule: ApprovalModelV5{Type: "group", Options: breakGlass}, want: true},
+		{rule: ApprovalModelV5{Type: "p0", Options: &ApprovalOptionsModelV2{BreakGlassApprover: ptr(false)}}},
+		{rule: ApprovalModelV5{Type: "p0"}},
+		{rule: ApprovalModelV5{Type: "auto", Integration: ptr("pagerduty"), Options: breakGlass}},
+		{rule: ApprovalModelV5{Type: "deny", Options: breakGlass}},
+	}
+	for _, c := range cases {
+		if got := isBreakGlassApprover(c.rule); got != c.want {
+			t.Errorf("isBreakGlassApprover(%+v) = %v; want %v", c.rule, got, c.want)
+		}
+	}
+}
@@ -4,9 +4,16 @@
 package accesspolicy
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/p0-security/terraform-provider-p0/internal/common"
+	"github.com/p0-security/terraform-provider-p0/internal/provider/resources/settings"
 )
 
 type GroupModelV1 struct {
@@ -70,6 +77,35 @@ type RequestorModelV3 struct {
 	User   *AgenticUserModel `json:"user,omitempty" tfsdk:"user"`
 }
 
+type RequestorModelV4 struct {
+	Type         string            `json:"type" tfsdk:"type"`
+	Groups       []GroupModelV1    `json:"groups,omitempty" tfsdk:"groups"`
+	Uid          *string           `json:"uid,omitempty" tfsdk:"uid"`
+	Effect       *string           `json:"effect,omitempty" tfsdk:"effect"`
+	Agent        *AgentModel       `tfsdk:"agent"`
+	User         *AgenticUserModel `json:"user,omitempty" tfsdk:"user"`
+	PrincipalSet *string           `json:"principalSet,omitempty" tfsdk:"principal_set"`
+}
+
+// RequestorProfileModel matches requestors by the attributes of their
+// identity provider profile: a profile matches if, for every attribute, its
+// value is one of the listed values.
+type RequestorProfileModel struct {
+	Directory  string              `json:"directory" tfsdk:"directory"`
+	Attributes map[string][]string `json:"attributes" tfsdk:"attributes"`
+}
+
+type RequestorModelV5 struct {
+	Type         string                 `json:"type" tfsdk:"type"`
+	Groups       []GroupModelV1         `json:"groups,omitempty" tfsdk:"groups"`
+	Uid          *string                `json:"uid,omitempty" tfsdk:"uid"`
+	Effect       *string                `json:"effect,omitempty" tfsdk:"effect"`
+	Agent        *AgentModel            `tfsdk:"agent"`
+	User         *AgenticUserModel      `json:"user,omitempty" tfsdk:"user"`
+	PrincipalSet *string                `json:"principalSet,omitempty" tfsdk:"principal_set"`
+	Profile      *RequestorProfileModel `json:"profile,omitempty" tfsdk:"profile"`
+}
+
 type ResourceFilterModel struct {
 	Effect  string  `json:"effect" tfsdk:"effect"`
 	Key     *string `json:"key" tfsdk:"key"`
@@ -92,6 +128,32 @@ type ApprovalOptionsModel struct {
 	RequireDuration    *bool `json:"requireDuration" tfsdk:"require_duration"`
 }
 
+type DurationModel struct {
+	Time int64  `json:"time" tfsdk:"time"`
+	Unit string `json:"unit" tfsdk:"unit"`
+}
+
+type ApprovalOptionsModelV1 struct {
+	AllowOneParty      *bool          `json:"allowOneParty" tfsdk:"allow_one_party"`
+	BreakGlassApprover *bool          `json:"breakGlassApprover" tfsdk:"break_glass_approver"`
+	RequirePreapproval *bool          `json:"requirePreapproval" tfsdk:"require_preapproval"`
+	RequireReason      *bool          `json:"requireReason" tfsdk:"require_reason"`
+	RequireDuration    *bool          `json:"requireDuration" tfsdk:"require_duration"`
+	MaxDuration        *DurationModel `json:"maxDuration,omitempty" tfsdk:"max_duration"`
+	ReasonPattern      *string        `json:"reasonPattern,omitempty" tfsdk:"reason_pattern"`
+}
+
+type ApprovalOptionsModelV2 struct {
+	AllowOneParty      *bool          `json:"allowOneParty" tfsdk:"allow_one_party"`
+	BreakGlassApprover *bool          `json:"breakGlassApprover" tfsdk:"break_glass_approver"`
+	RequirePreapproval *bool          `json:"requirePreapproval" tfsdk:"require_preapproval"`
+	RequireReason      *bool          `json:"requireReason" tfsdk:"require_reason"`
+	RequireDuration    *bool          `json:"requireDuration" tfsdk:"require_duration"`
+	MaxDuration        *DurationModel `json:"maxDuration,omitempty" tfsdk:"max_duration"`
+	ReasonPattern      *string        `json:"reasonPattern,omitempty" tfsdk:"reason_pattern"`
+	MinApprovers       *int64         `json:"minApprovers,omitempty" tfsdk:"min_approvers"`
+}
+
 type ApprovalModelV0 struct {
 	Directory       *string               `json:"directory" tfsdk:"directory"`
 	Id              *string               `json:"id" tfsdk:"id"`
@@ -124,6 +186,42 @@ type ApprovalModelV2 struct {
 	Effect          *string               `json:"effect" tfsdk:"effect"`
 }
 
+type ApprovalModelV3 struct {
+	Directory       *string                 `json:"directory" tfsdk:"directory"`
+	Integration     *string                 `json:"integration" tfsdk:"integration"`
+	Groups          []GroupModelV1          `json:"groups" tfsdk:"groups"`
+	ProfileProperty *string                 `json:"profileProperty" tfsdk:"profile_property"`
+	Options         *ApprovalOptionsModelV1 `json:"options" tfsdk:"options"`
+	Services        *[]string               `json:"services" tfsdk:"services"`
+	Type            string                  `json:"type" tfsdk:"type"`
+	Effect          *string                 `json:"effect" tfsdk:"effect"`
+}
+
+type ApprovalModelV4 struct {
+	Directory       *string                 `json:"directory" tfsdk:"directory"`
+	Integration     *string                 `json:"integration" tfsdk:"integration"`
+	Groups          []GroupModelV1          `json:"groups" tfsdk:"groups"`
+	ProfileProperty *string                 `json:"profileProperty" tfsdk:"profile_property"`
+	Options         *ApprovalOptionsModelV2 `json:"options" tfsdk:"options"`
+	Services        *[]string               `json:"services" tfsdk:"services"`
+	Type            string                  `json:"type" tfsdk:"type"`
+	Effect          *string                 `json:"effect" tfsdk:"effect"`
+	Stage           *int64                  `json:"stage,omitempty" tfsdk:"stage"`
+}
+
+type ApprovalModelV5 struct {
+	Directory       *string                 `json:"directory" tfsdk:"directory"`
+	Integration     *string                 `json:"integration" tfsdk:"integration"`
+	Groups          []GroupModelV1          `json:"groups" tfsdk:"groups"`
+	ProfileProperty *string                 `json:"profileProperty" tfsdk:"profile_property"`
+	Options         *ApprovalOptionsModelV2 `json:"options" tfsdk:"options"`
+	Services        *[]string               `json:"services" tfsdk:"services"`
+	Type            string                  `json:"type" tfsdk:"type"`
+	Effect          *string                 `json:"effect" tfsdk:"effect"`
+	Stage           *int64                  `json:"stage,omitempty" tfsdk:"stage"`
+	PrincipalSet    *string                 `json:"principalSet,omitempty" tfsdk:"principal_set"`
+}
+
 type AccessPolicyModelV0 struct {
 	Name      *string           `json:"name" tfsdk:"name"`
 	Requestor *RequestorModelV0 `json:"requestor" tfsdk:"requestor"`
@@ -147,6 +245,8 @@ type AccessPolicyModelV2 struct {
 }
 
 type AccessPolicyModelV3 struct {
+	// Id is unknown until the policy is created.
+	Id        types.String      `tfsdk:"id"`
 	Name      *string           `json:"name" tfsdk:"name"`
 	Disabled  *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
 	Requestor *RequestorModelV3 `json:"requestor" tfsdk:"requestor"`
@@ -154,7 +254,162 @@ type AccessPolicyModelV3 struct {
 	Approval  []ApprovalModelV2 `json:"approval" tfsdk:"approval"`
 }
 
-const currentSchemaVersion int64 = 3
+type AccessPolicyModelV4 struct {
+	// Id is unknown until the policy is created.
+	Id        types.String      `tfsdk:"id"`
+	Name      *string           `json:"name" tfsdk:"name"`
+	Disabled  *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor *RequestorModelV3 `json:"requestor" tfsdk:"requestor"`
+	Resource  *ResourceModel    `json:"resource" tfsdk:"resource"`
+	Approval  []ApprovalModelV3 `json:"approval" tfsdk:"approval"`
+}
+
+type AccessPolicyModelV5 struct {
+	// Id is unknown until the policy is created.
+	Id        types.String      `tfsdk:"id"`
+	Name      *string           `json:"name" tfsdk:"name"`
+	Disabled  *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor *RequestorModelV3 `json:"requestor" tfsdk:"requestor"`
+	Resource  *ResourceModel    `json:"resource" tfsdk:"resource"`
+	Approval  []ApprovalModelV3 `json:"approval" tfsdk:"approval"`
+	Schedule  *ScheduleModel    `json:"schedule,omitempty" tfsdk:"schedule"`
+}
+
+type AccessPolicyModelV6 struct {
+	// Id is unknown until the policy is created.
+	Id        types.String      `tfsdk:"id"`
+	Name      *string           `json:"name" tfsdk:"name"`
+	Disabled  *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor *RequestorModelV3 `json:"requestor" tfsdk:"requestor"`
+	Resource  *ResourceModel    `json:"resource" tfsdk:"resource"`
+	Approval  []ApprovalModelV4 `json:"approval" tfsdk:"approval"`
+	Schedule  *ScheduleModel    `json:"schedule,omitempty" tfsdk:"schedule"`
+}
+
+type AccessPolicyModelV7 struct {
+	// Id is unknown until the policy is created.
+	Id         types.String      `tfsdk:"id"`
+	Name       *string           `json:"name" tfsdk:"name"`
+	Disabled   *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor  *RequestorModelV3 `json:"requestor" tfsdk:"requestor"`
+	Resource   *ResourceModel    `json:"resource" tfsdk:"resource"`
+	Approval   []ApprovalModelV4 `json:"approval" tfsdk:"approval"`
+	Schedule   *ScheduleModel    `json:"schedule,omitempty" tfsdk:"schedule"`
+	BreakGlass *BreakGlassModel  `json:"breakGlass,omitempty" tfsdk:"break_glass"`
+}
+
+type AccessPolicyModelV8 struct {
+	// Id is unknown until the policy is created.
+	Id         types.String      `tfsdk:"id"`
+	Name       *string           `json:"name" tfsdk:"name"`
+	Disabled   *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor  *RequestorModelV4 `json:"requestor" tfsdk:"requestor"`
+	Resource   *ResourceModel    `json:"resource" tfsdk:"resource"`
+	Approval   []ApprovalModelV5 `json:"approval" tfsdk:"approval"`
+	Schedule   *ScheduleModel    `json:"schedule,omitempty" tfsdk:"schedule"`
+	BreakGlass *BreakGlassModel  `json:"breakGlass,omitempty" tfsdk:"break_glass"`
+}
+
+type AccessPolicyModelV9 struct {
+	// Id is unknown until the policy is created.
+	Id             types.String      `tfsdk:"id"`
+	Name           *string           `json:"name" tfsdk:"name"`
+	Disabled       *bool             `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor      *RequestorModelV4 `json:"requestor" tfsdk:"requestor"`
+	Resource       *ResourceModel    `json:"resource" tfsdk:"resource"`
+	Approval       []ApprovalModelV5 `json:"approval" tfsdk:"approval"`
+	Schedule       *ScheduleModel    `json:"schedule,omitempty" tfsdk:"schedule"`
+	BreakGlass     *BreakGlassModel  `json:"breakGlass,omitempty" tfsdk:"break_glass"`
+	LastModifiedBy types.String      `tfsdk:"last_modified_by"`
+	LastModifiedAt types.String      `tfsdk:"last_modified_at"`
+}
+
+type AccessPolicyModelV10 struct {
+	// Id is unknown until the policy is created.
+	Id             types.String        `tfsdk:"id"`
+	Name           *string             `json:"name" tfsdk:"name"`
+	Disabled       *bool               `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor      *RequestorModelV4   `json:"requestor" tfsdk:"requestor"`
+	Resource       *ResourceModel      `json:"resource" tfsdk:"resource"`
+	Approval       []ApprovalModelV5   `json:"approval" tfsdk:"approval"`
+	Schedule       *ScheduleModel      `json:"schedule,omitempty" tfsdk:"schedule"`
+	BreakGlass     *BreakGlassModel    `json:"breakGlass,omitempty" tfsdk:"break_glass"`
+	Notifications  []NotificationModel `json:"notifications,omitempty" tfsdk:"notifications"`
+	LastModifiedBy types.String        `tfsdk:"last_modified_by"`
+	LastModifiedAt types.String        `tfsdk:"last_modified_at"`
+}
+
+type AccessPolicyModelV11 struct {
+	// Id is unknown until the policy is created.
+	Id             types.String        `tfsdk:"id"`
+	Name           *string             `json:"name" tfsdk:"name"`
+	Disabled       *bool               `json:"disabled,omitempty" tfsdk:"disabled"`
+	Requestor      *RequestorModelV5   `json:"requestor" tfsdk:"requestor"`
+	Resource       *ResourceModel      `json:"resource" tfsdk:"resource"`
+	Approval       []ApprovalModelV5   `json:"approval" tfsdk:"approval"`
+	Schedule       *ScheduleModel      `json:"schedule,omitempty" tfsdk:"schedule"`
+	BreakGlass     *BreakGlassModel    `json:"breakGlass,omitempty" tfsdk:"break_glass"`
+	Notifications  []NotificationModel `json:"notifications,omitempty" tfsdk:"notifications"`
+	LastModifiedBy types.String        `tfsdk:"last_modified_by"`
+	LastModifiedAt types.String        `tfsdk:"last_modified_at"`
+}
+
+const currentSchemaVersion int64 = 11
+
+// Type-conditional requirements of the current schema's discriminated unions,
+// enforced by the schema validators and by the policy-building provider
+// functions (see functions.go).
+var (
+	requestorTypeRequirements = map[string][]string{
+		"user":          {"uid"},
+		"group":         {"groups", "effect"},
+		"agentic":       {"agent", "user"},
+		"principal-set": {"principal_set"},
+		"profile":       {"profile", "effect"},
+	}
+	// The API ignores a principal set or profile on other requestor and
+	// approval types, which would otherwise hide a mistyped `type`.
+	requestorTypeExclusives = map[string][]string{
+		"principal-set": {"principal_set"},
+		"profile":       {"profile"},
+	}
+	agentTypeRequirements = map[string][]string{
+		"agent-client": {"client_id"},
+		// Deprecated alias for "agent-client"; the API accepts both.
+		"mcp-client":  {"client_id"},
+		"agent-owner": {"owner"},
+		"owner-group": {"groups", "effect"},
+		"provider":    {"provider_id"},
+	}
+	// agentToJson only forwards `groups`/`effect` to the API when `type` is
+	// "owner-group"; without this, setting them for any other type would
+	// silently vanish instead of failing the plan.
+	agentTypeExclusives = map[string][]string{
+		"owner-group": {"groups", "effect"},
+	}
+	agenticUserTypeRequirements = map[string][]string{
+		"user":  {"uid"},
+		"group": {"groups", "effect"},
+	}
+	resourceTypeRequirements = map[string][]string{
+		"integration": {"service"},
+	}
+	approvalTypeRequirements = map[string][]string{
+		"auto":              {"integration"},
+		"escalation":        {"integration", "services"},
+		"group":             {"groups", "effect"},
+		"requestor-profile": {"directory"},
+		"principal-set":     {"principal_set"},
+	}
+	approvalTypeExclusives = map[string][]string{
+		"principal-set": {"principal_set"},
+	}
+	// Approval rule types that are satisfied by people, and so may require a
+	// quorum of them.
+	quorumApprovalTypes = []string{"escalation", "group", "p0", "principal-set"}
+	// Directories whose user profiles P0 reads.
+	profileDirectories = []string{"azure-ad", "entra-id", "okta", "workspace"}
+)
 
 // requestorUnionAttributes builds the `type`/`uid`/`groups`/`effect`
 // attributes shared by the top-level `requestor` object and the `agentic`
@@ -176,15 +431,27 @@ func requestorAttribute(version int64) schema.SingleNestedAttribute {
     - 'any': Any requestor will match
     - 'group': Members of a directory group will match
     - 'user': Only match a single user
-    - 'agentic': Match agent sessions, based on the agent's identity and the human user (if any) behind it`)
-	requirements := map[string][]string{
-		"user":  {"uid"},
-		"group": {"groups", "effect"},
-	}
-	if version >= currentSchemaVersion {
+    - 'agentic': Match agent sessions, based on the agent's identity and the human user (if any) behind it
+    - 'principal-set': Members of a `+"`p0_principal_set`"+` will match
+    - 'profile': Requestors whose directory profile attributes match will match`)
+	// The agentic requestor postdates schema version 2.
+	if version >= 3 {
 		attributes["agent"] = agentAttribute(version)
 		attributes["user"] = agenticUserAttribute(version)
-		requirements["agentic"] = []string{"agent", "user"}
+	}
+	// Principal sets postdate schema version 7.
+	if version >= 8 {
+		attributes["principal_set"] = principalSetReferenceAttribute()
+	}
+	// Profile conditions postdate schema version 10.
+	if version >= 11 {
+		attributes["profile"] = requestorProfileAttribute()
+		attributes["effect"] = schema.StringAttribute{
+			MarkdownDescription: `Required, and may only be used, if 'type' is 'group' or 'profile'. The filter effect. May be one of:
+	 - 'keep': Access rule only applies when a requestor is a member of any of the specified groups, or their profile matches
+	 - 'remove': Access rule only applies when a requestor is _not_ a member of any of the specified groups, or their profile does _not_ match`,
+			Optional: true,
+		}
 	}
 	attribute := schema.SingleNestedAttribute{
 		Required:            true,
@@ -192,16 +459,46 @@ func requestorAttribute(version int64) schema.SingleNestedAttribute {
 		Attributes:          attributes,
 	}
 	// Only the current schema validates configuration; earlier versions exist
-	// solely to decode prior state. The `agentic` requirement also references
-	// `agent`/`user`, which only exist at the current version.
+	// solely to decode prior state.
 	if version >= currentSchemaVersion {
 		attribute.Validators = []validator.Object{
-			RequiredWhenType(requirements),
+			RequiredWhenType(requestorTypeRequirements),
+			ExclusiveToType(requestorTypeExclusives),
 		}
 	}
 	return attribute
 }
 
+// requestorProfileAttribute builds the `requestor.profile` schema, which only
+// exists at schema version 11 and later.
+func requestorProfileAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: `Required, and may only be used, if 'type' is 'profile'. Matches requestors by the attributes of their
+directory profile, such as department, employment type, or location; see 'effect'.`,
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"directory": schema.StringAttribute{
+				MarkdownDescription: `The directory that holds the profiles. One of ` + quotedList(profileDirectories) + `.`,
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(profileDirectories...),
+				},
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: `The values to match, keyed by the name of the profile attribute in the directory (e.g., 'department',
+'employeeType', or 'city'). A profile matches if, for every attribute, its value is one of the listed values.`,
+				ElementType: types.ListType{ElemType: types.StringType},
+				CustomType:  ProfileAttributesType{MapType: basetypes.MapType{ElemType: types.ListType{ElemType: types.StringType}}},
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.ValueListsAre(listvalidator.SizeAtLeast(1), listvalidator.UniqueValues()),
+				},
+			},
+		},
+	}
+}
+
 // agentAttribute builds the `requestor.agent` schema: a discriminated union
 // describing the agent's own identity, used only when `requestor.type` is
 // 'agentic'.
@@ -224,8 +521,9 @@ func agentAttribute(version int64) schema.SingleNestedAttribute {
 				Optional:            true,
 			},
 			"subject_pattern": schema.StringAttribute{
-				MarkdownDescription: `May only be used if 'type' is 'provider'. An optional regular expression used to further narrow the agent's subject claim.`,
+				MarkdownDescription: `May only be used if 'type' is 'provider'. An optional regular expression used to further narrow the agent's subject claim. ` + common.PatternSyntaxDescription,
 				Optional:            true,
+				Validators:          []validator.String{common.PatternValidator()},
 			},
 		}))
 	// AttachGroupAttributes/AttachGroupFilterEffectAttribute's descriptions
@@ -245,20 +543,8 @@ func agentAttribute(version int64) schema.SingleNestedAttribute {
 		MarkdownDescription: `Required, and may only be used, if the requestor 'type' is 'agentic'. Describes the agent's own identity.`,
 		Attributes:          attributes,
 		Validators: []validator.Object{
-			RequiredWhenType(map[string][]string{
-				"agent-client": {"client_id"},
-				// Deprecated alias for "agent-client"; the API accepts both.
-				"mcp-client":  {"client_id"},
-				"agent-owner": {"owner"},
-				"owner-group": {"groups", "effect"},
-				"provider":    {"provider_id"},
-			}),
-			// agentToJson only forwards `groups`/`effect` to the API when
-			// `type` is "owner-group"; without this, setting them for any
-			// other type would silently vanish instead of failing the plan.
-			ExclusiveToType(map[string][]string{
-				"owner-group": {"groups", "effect"},
-			}),
+			RequiredWhenType(agentTypeRequirements),
+			ExclusiveToType(agentTypeExclusives),
 		},
 	}
 }
@@ -289,10 +575,7 @@ func agenticUserAttribute(version int64) schema.SingleNestedAttribute {
 		MarkdownDescription: `Required, and may only be used, if the requestor 'type' is 'agentic'. Describes the human user (if any) behind the agent.`,
 		Attributes:          attributes,
 		Validators: []validator.Object{
-			RequiredWhenType(map[string][]string{
-				"user":  {"uid"},
-				"group": {"groups", "effect"},
-			}),
+			RequiredWhenType(agenticUserTypeRequirements),
 		},
 	}
 }
@@ -301,9 +584,7 @@ var resourceAttribute = schema.SingleNestedAttribute{
 	Required:            true,
 	MarkdownDescription: `Controls what is accessed. See [the Resource docs](https://docs.p0.dev/just-in-time-access/request-routing#resource).`,
 	Validators: []validator.Object{
-		RequiredWhenType(map[string][]string{
-			"integration": {"service"},
-		}),
+		RequiredWhenType(resourceTypeRequirements),
 	},
 	Attributes: map[string]schema.Attribute{
 		"filters": schema.MapNestedAttribute{
@@ -328,8 +609,9 @@ See [docs](https://docs.p0.dev/just-in-time-access/request-routing#resource) for
 						Optional:            true,
 					},
 					"pattern": schema.StringAttribute{
-						MarkdownDescription: `Filter patterns. Patterns are unanchored.`,
+						MarkdownDescription: "Filter patterns. " + common.PatternSyntaxDescription,
 						Optional:            true,
+						Validators:          []validator.String{common.PatternValidator()},
 					},
 				},
 			},
@@ -337,7 +619,8 @@ See [docs](https://docs.p0.dev/just-in-time-access/request-routing#resource) for
 		},
 		"service": schema.StringAttribute{
 			MarkdownDescription: `Required, and may only be used, if 'type' is 'integration'.
-See [the Resource docs](https://docs.p0.dev/just-in-time-access/request-routing#resource) for a list of available services.`,
+See [the Resource docs](https://docs.p0.dev/just-in-time-access/request-routing#resource) for a list of available services.
+The service, 'access_type', and filter keys are checked against your organization's installed integrations when planning.`,
 			Optional: true,
 		},
 		"type": schema.StringAttribute{
@@ -353,6 +636,60 @@ See [the Resource docs](https://docs.p0.dev/just-in-time-access/request-routing#
 	},
 }
 
+func approvalOptionsAttribute(version int64) schema.SingleNestedAttribute {
+	attribute := schema.SingleNestedAttribute{
+		MarkdownDescription: `If present, determines additional trust requirements.`,
+		Attributes: map[string]schema.Attribute{
+			"allow_one_party": schema.BoolAttribute{
+				MarkdownDescription: `If true, allows requestors to approve their own requests. Does not apply to 'auto' approval rules.`,
+				Optional:            true,
+			},
+			"require_reason": schema.BoolAttribute{
+				MarkdownDescription: `If true, requires access requests to include a reason.`,
+				Optional:            true,
+			},
+			"require_duration": schema.BoolAttribute{
+				MarkdownDescription: `If true, requires access requests to include a duration.`,
+				Optional:            true,
+			},
+			"require_preapproval": schema.BoolAttribute{
+				MarkdownDescription: `If true, requires access requests to be pre-approved.`,
+				Optional:            true,
+			},
+			"break_glass_approver": schema.BoolAttribute{
+				MarkdownDescription: `If true, allows the approver to approve break-glass requests. Does not apply to 'auto' approval rules.`,
+				Optional:            true,
+			},
+		},
+		Optional: true,
+	}
+	// Per-rule request constraints postdate schema version 3.
+	if version >= 4 {
+		attribute.Attributes["max_duration"] = schema.SingleNestedAttribute{
+			MarkdownDescription: `The longest access duration that may be requested under this approval rule. This may only shorten the
+organization-wide maximum (see ` + "`p0_access_durations`" + `). Use ` + "`provider::p0::duration`" + ` to write it as a string, e.g.
+` + "`max_duration = provider::p0::duration(\"4h\")`" + `.`,
+			Optional:   true,
+			Attributes: settings.DurationAttributes(),
+		}
+		attribute.Attributes["reason_pattern"] = schema.StringAttribute{
+			MarkdownDescription: `If present, access requests must include a reason matching this pattern (e.g. a ticket number). ` + common.PatternSyntaxDescription,
+			Optional:            true,
+			Validators:          []validator.String{common.PatternValidator()},
+		}
+	}
+	// Quorums postdate schema version 5.
+	if version >= 6 {
+		attribute.Attributes["min_approvers"] = schema.Int64Attribute{
+			MarkdownDescription: `The number of distinct approvers required to satisfy this approval rule; if omitted, one. May only be used if
+'type' is one of ` + quotedList(quorumApprovalTypes) + `.`,
+			Optional:   true,
+			Validators: []validator.Int64{int64validator.AtLeast(1)},
+		}
+	}
+	return attribute
+}
+
 func approvalAttribute(version int64) schema.ListNestedAttribute {
 	nestedObject := schema.NestedAttributeObject{
 		Attributes: AttachGroupFilterEffectAttribute(version, AttachGroupAttributes(version, map[string]schema.Attribute{
@@ -366,32 +703,7 @@ func approvalAttribute(version int64) schema.ListNestedAttribute {
 - 'incidentio': Access is granted if the requestor is on-call in incident.io.`,
 				Optional: true,
 			},
-			"options": schema.SingleNestedAttribute{
-				MarkdownDescription: `If present, determines additional trust requirements.`,
-				Attributes: map[string]schema.Attribute{
-					"allow_one_party": schema.BoolAttribute{
-						MarkdownDescription: `If true, allows requestors to approve their own requests. Does not apply to 'auto' approval rules.`,
-						Optional:            true,
-					},
-					"require_reason": schema.BoolAttribute{
-						MarkdownDescription: `If true, requires access requests to include a reason.`,
-						Optional:            true,
-					},
-					"require_duration": schema.BoolAttribute{
-						MarkdownDescription: `If true, requires access requests to include a duration.`,
-						Optional:            true,
-					},
-					"require_preapproval": schema.BoolAttribute{
-						MarkdownDescription: `If true, requires access requests to be pre-approved.`,
-						Optional:            true,
-					},
-					"break_glass_approver": schema.BoolAttribute{
-						MarkdownDescription: `If true, allows the approver to approve break-glass requests. Does not apply to 'auto' approval rules.`,
-						Optional:            true,
-					},
-				},
-				Optional: true,
-			},
+			"options": approvalOptionsAttribute(version),
 			"profile_property": schema.StringAttribute{
 				MarkdownDescription: `May only be used if 'type' is 'requestor-profile'. This is the profile attribute that contains the manager's email.`,
 				Optional:            true,
@@ -402,33 +714,52 @@ func approvalAttribute(version int64) schema.ListNestedAttribute {
 				Optional:            true,
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: `Determines trust requirements for access. If empty, access is disallowed. Except for 'deny', meeting any requirement is sufficient to grant access. Possible values:
+				MarkdownDescription: `Determines trust requirements for access. If empty, access is disallowed. Except for 'deny', meeting any requirement of each 'stage' is sufficient to grant access. Possible values:
     - 'auto': Access is granted according to the requirements of the specified 'integration'
     - 'deny': Access is always denied
     - 'escalation': Access may be approved by on-call members of the specified services, who are paged when access is manually escalated by the requestor
     - 'group': Access may be granted by any member of the defined directory group
     - 'persistent': Access is always granted
     - 'requestor-profile': Allows approval by a user specified by a field in the requestor's IDP profile
-    - 'p0': Access may be granted by any user with the P0 "security reviewer" role (defined in the P0 app)`,
+    - 'p0': Access may be granted by any user with the P0 "security reviewer" role (defined in the P0 app)
+    - 'principal-set': Access may be granted by any member of a ` + "`p0_principal_set`" + ``,
 				Required: true,
 			},
 		})),
 	}
-	// `groups` and `effect` only exist from schema version 2 onward, so only the
-	// current schema can enforce their type-conditional requiredness.
-	if version >= currentSchemaVersion {
-		nestedObject.Validators = []validator.Object{
-			RequiredWhenType(map[string][]string{
-				"auto":              {"integration"},
-				"escalation":        {"integration", "services"},
-				"group":             {"groups", "effect"},
-				"requestor-profile": {"directory"},
-			}),
+	// Sequential stages postdate schema version 5.
+	if version >= 6 {
+		nestedObject.Attributes["stage"] = schema.Int64Attribute{
+			MarkdownDescription: `The approval stage this rule belongs to; if omitted, 1. Stages are satisfied in order: once any rule of a stage
+is met, the request proceeds to the next stage, and access is granted once the last stage is met. Stages must be
+numbered consecutively from 1. May not be used if 'type' is 'deny', which always applies.`,
+			Optional:   true,
+			Validators: []validator.Int64{int64validator.AtLeast(1)},
 		}
 	}
-	return schema.ListNestedAttribute{
+	// Principal sets postdate schema version 7.
+	if version >= 8 {
+		nestedObject.Attributes["principal_set"] = principalSetReferenceAttribute()
+	}
+	attribute := schema.ListNestedAttribute{
 		MarkdownDescription: `Determines access requirements. See [the Approval docs](https://docs.p0.dev/just-in-time-access/request-routing#approval).`,
 		Required:            true,
 		NestedObject:        nestedObject,
 	}
+	// `groups` and `effect` only exist from schema version 2 onward, and
+	// `stage` and `min_approvers` from version 6, so only the current schema
+	// can enforce their type-conditional constraints. Likewise, only the
+	// current schema is compared to the API's responses.
+	if version >= currentSchemaVersion {
+		attribute.NestedObject.Validators = []validator.Object{
+			RequiredWhenType(approvalTypeRequirements),
+			ExclusiveToType(approvalTypeExclusives),
+			ApprovalRuleConstraints(),
+		}
+		attribute.Validators = []validator.List{
+			ConsecutiveApprovalStages(),
+		}
+		attribute.CustomType = ApprovalsType{ListType: basetypes.ListType{ElemType: nestedObject.Type()}}
+	}
+	return attribute
 }
@@ -0,0 +1,339 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The analysis here compares pairs of access policies to find policies that
+// can never take effect, or that disagree about the same requests. It is
+// conservative: a policy only covers another if every request the other
+// matches is certain to match it too, and two policies only overlap if some
+// request is likely to match both. Patterns, filters, and 'remove' group
+// effects are only compared for equality, so some conflicts go unreported, in
+// exchange for few spurious warnings.
+
+// policyConflict is a warning about a pair of policies.
+type policyConflict struct {
+	Summary string
+	Detail  string
+}
+
+// requestorCovers reports whether every requestor that b matches is also
+// matched by a.
+func requestorCovers(a *RequestorModelV5, b *RequestorModelV5) bool {
+	if a == nil || a.Type == "any" {
+		return true
+	}
+	if b == nil || a.Type != b.Type {
+		return false
+	}
+	switch a.Type {
+	case "user":
+		return equalFold(a.Uid, b.Uid)
+	case "group":
+		// Members of any of b's groups are members of one of a's groups.
+		if isKeep(a.Effect) && isKeep(b.Effect) {
+			aKeys := groupKeys(a.Groups)
+			return !slices.ContainsFunc(groupKeys(b.Groups), func(key string) bool {
+				return !slices.Contains(aKeys, key)
+			})
+		}
+	case "profile":
+		// Profiles matching all of b's conditions match all of a's.
+		if isKeep(a.Effect) && isKeep(b.Effect) && a.Profile != nil && b.Profile != nil {
+			return profileCovers(*a.Profile, *b.Profile)
+		}
+	}
+	return requestorsEqual(a, b)
+}
+
+// requestorsOverlap reports whether some requestor is certainly matched by
+// both a and b.
+func requestorsOverlap(a *RequestorModelV5, b *RequestorModelV5) bool {
+	if requestorCovers(a, b) || requestorCovers(b, a) {
+		return true
+	}
+	// Members of a group in both policies.
+	if a.Type == "group" && b.Type == "group" && isKeep(a.Effect) && isKeep(b.Effect) {
+		bKeys := groupKeys(b.Groups)
+		return slices.ContainsFunc(groupKeys(a.Groups), func(key string) bool {
+			return slices.Contains(bKeys, key)
+		})
+	}
+	return false
+}
+
+// profileCovers reports whether every profile that b matches is also matched
+// by a: each of a's attributes is constrained by b to a subset of a's values.
+func profileCovers(a RequestorProfileModel, b RequestorProfileModel) bool {
+	if a.Directory != b.Directory {
+		return false
+	}
+	for name, aValues := range a.Attributes {
+		bValues, ok := b.Attributes[name]
+		if !ok || slices.ContainsFunc(bValues, func(value string) bool {
+			return !slices.Contains(aValues, value)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func isKeep(effect *string) bool {
+	return effect == nil || *effect == "keep"
+}
+
+func isAnyAccessType(accessType *string) bool {
+	return accessType == nil || *accessType == "any"
+}
+
+// resourceCovers reports whether every resource that b matches is also
+// matched by a.
+func resourceCovers(a *ResourceModel, b *ResourceModel) bool {
+	if a == nil || a.Type == "any" {
+		return true
+	}
+	if b == nil || b.Type == "any" || !equalString(a.Service, b.Service) {
+		return false
+	}
+	if !isAnyAccessType(a.AccessType) && (isAnyAccessType(b.AccessType) || *a.AccessType != *b.AccessType) {
+		return false
+	}
+	return a.Filters == nil || len(*a.Filters) == 0 || (b.Filters != nil && reflect.DeepEqual(*a.Filters, *b.Filters))
+}
+
+// resourcesOverlap reports whether some resource is likely matched by both a
+// and b.
+func resourcesOverlap(a *ResourceModel, b *ResourceModel) bool {
+	if resourceCovers(a, b) || resourceCovers(b, a) {
+		return true
+	}
+	// The same service, with compatible access types, and at most one
+	// policy's filters.
+	if !equalString(a.Service, b.Service) {
+		return false
+	}
+	if !isAnyAccessType(a.AccessType) && !isAnyAccessType(b.AccessType) && *a.AccessType != *b.AccessType {
+		return false
+	}
+	return a.Filters == nil || len(*a.Filters) == 0 || b.Filters == nil || len(*b.Filters) == 0
+}
+
+func equalString(a *string, b *string) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// scheduleCovers reports whether a applies whenever b does.
+func scheduleCovers(a *ScheduleModel, b *ScheduleModel) bool {
+	return a == nil || (b != nil && reflect.DeepEqual(*a, *b))
+}
+
+// policyCovers reports whether every request that b matches is also matched
+// by a.
+func policyCovers(a AccessPolicyModelV11, b AccessPolicyModelV11) bool {
+	return requestorCovers(a.Requestor, b.Requestor) && resourceCovers(a.Resource, b.Resource) && scheduleCovers(a.Schedule, b.Schedule)
+}
+
+// policiesOverlap reports whether some request is likely matched by both a
+// and b.
+func policiesOverlap(a AccessPolicyModelV11, b AccessPolicyModelV11) bool {
+	return requestorsOverlap(a.Requestor, b.Requestor) &&
+		resourcesOverlap(a.Resource, b.Resource) &&
+		(scheduleCovers(a.Schedule, b.Schedule) || scheduleCovers(b.Schedule, a.Schedule))
+}
+
+// denies reports whether a policy denies every request it matches.
+func denies(policy AccessPolicyModelV11) bool {
+	return len(policy.Approval) == 0 || slices.ContainsFunc(policy.Approval, func(a ApprovalModelV5) bool {
+		return a.Type == "deny"
+	})
+}
+
+func isDisabled(policy AccessPolicyModelV11) bool {
+	return policy.Disabled != nil && *policy.Disabled
+}
+
+func policyName(policy AccessPolicyModelV11) string {
+	if policy.Name == nil {
+		return ""
+	}
+	return *policy.Name
+}
+
+// comparePolicies returns the conflicts between two enabled policies:
+//   - shadowed: a policy never grants access, because a policy that matches
+//     all of its requests denies them, or never has an effect, because a
+//     policy that matches all of its requests has the same approval rules
+//   - contradictory: one policy denies some requests that the other grants
+//   - overlapping: both policies match exactly the same requests, with
+//     different approval rules
+func comparePolicies(a AccessPolicyModelV11, b AccessPolicyModelV11) []policyConflict {
+	if isDisabled(a) || isDisabled(b) || !policiesOverlap(a, b) {
+		return nil
+	}
+	// A break-glass route is meant to overlap a regular policy.
+	if (a.BreakGlass == nil) != (b.BreakGlass == nil) {
+		return nil
+	}
+	aName, bName := policyName(a), policyName(b)
+	aCovers, bCovers := policyCovers(a, b), policyCovers(b, a)
+
+	switch aDenies, bDenies := denies(a), denies(b); {
+	case aDenies && !bDenies:
+		return []policyConflict{denyConflict(aName, bName, aCovers)}
+	case bDenies && !aDenies:
+		return []policyConflict{denyConflict(bName, aName, bCovers)}
+	}
+
+	if aCovers && bCovers {
+		if approvalsEqual(a.Approval, b.Approval) {
+			return []policyConflict{{
+				Summary: "Duplicate access policies",
+				Detail:  fmt.Sprintf("Access policies %q and %q match the same requests with the same approval rules; one of them can be removed.", aName, bName),
+			}}
+		}
+		if denies(a) {
+			return nil
+		}
+		return []policyConflict{{
+			Summary: "Overlapping access policies",
+			Detail:  fmt.Sprintf("Access policies %q and %q match exactly the same requests, but with different approval rules. Consider merging them into a single policy.", aName, bName),
+		}}
+	}
+	if broader, narrower, ok := coveringPair(a, b, aCovers, bCovers); ok {
+		if !approvalsEqual(broader.Approval, narrower.Approval) {
+			return nil
+		}
+		return []policyConflict{{
+			Summary: "Shadowed access policy",
+			Detail:  fmt.Sprintf("Access policy %q has no effect: %q matches all of its requests, with the same approval rules.", policyName(narrower), policyName(broader)),
+		}}
+	}
+	return nil
+}
+
+// coveringPair orders a and b as (broader, narrower) if one covers the other.
+func coveringPair(a AccessPolicyModelV11, b AccessPolicyModelV11, aCovers bool, bCovers bool) (AccessPolicyModelV11, AccessPolicyModelV11, bool) {
+	switch {
+	case aCovers:
+		return a, b, true
+	case bCovers:
+		return b, a, true
+	}
+	return a, b, false
+}
+
+func denyConflict(denying string, granting string, covers bool) policyConflict {
+	if covers {
+		return policyConflict{
+			Summary: "Shadowed access policy",
+			Detail:  fmt.Sprintf("Access policy %q never grants access: %q matches all of its requests, and denies them.", granting, denying),
+		}
+	}
+	return policyConflict{
+		Summary: "Contradictory access policies",
+		Detail:  fmt.Sprintf("Access policy %q denies some of the requests that %q grants; those requests will be denied.", denying, granting),
+	}
+}
+
+// addConflictWarnings analyzes policy against others and adds a warning for
+// each conflict.
+func addConflictWarnings(diags *diag.Diagnostics, policy AccessPolicyModelV11, others []AccessPolicyModelV11) {
+	for _, other := range others {
+		for _, conflict := range comparePolicies(policy, other) {
+			diags.AddWarning(conflict.Summary, conflict.Detail)
+		}
+	}
+}
+
+// analyzeConflicts warns about conflicts between the planned policy and the
+// organization's other existing policies. Each p0_access_policy is planned on
+// its own, so policies planned in the same run are never compared with each
+// other; p0_access_policies, which plans a set, compares them (see
+// AccessPolicies.analyzeConflicts). Since the analysis is advisory, it is
+// skipped if the plan has unknown values or the policies can't be listed.
+func (policy *AccessPolicy) analyzeConflicts(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only analyze new or changed policies, so that warnings aren't repeated
+	// on every plan.
+	if !req.State.Raw.IsNull() && req.State.Raw.Equal(req.Plan.Raw) {
+		return
+	}
+	var planned AccessPolicyModelV11
+	if diags := req.Plan.Get(ctx, &planned); diags.HasError() || planned.Name == nil {
+		tflog.Debug(ctx, "Access policy plan is not fully known, skipping conflict analysis")
+		return
+	}
+	var prior AccessPolicyModelV11
+	if !req.State.Raw.IsNull() {
+		if diags := req.State.Get(ctx, &prior); diags.HasError() {
+			return
+		}
+	}
+
+	existing, _, err := listCachedPolicies(policy.data)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list access policies, skipping conflict analysis: %s", err))
+		return
+	}
+	var others []AccessPolicyModelV11
+	for _, name := range sortedNames(existing) {
+		json := existing[name]
+		// Skip this policy itself, including under its prior name or id.
+		if name == *planned.Name || (prior.Name != nil && name == *prior.Name) || (json.Id != nil && !prior.Id.IsNull() && *json.Id == prior.Id.ValueString()) {
+			continue
+		}
+		others = append(others, toModel(json))
+	}
+	addConflictWarnings(&resp.Diagnostics, planned, others)
+}
+
+// analyzeConflicts warns about conflicts between the declared policies (and,
+// if they are kept, the undeclared existing policies).
+func (r *AccessPolicies) analyzeConflicts(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, unmanaged []string, kept bool) {
+	if !req.State.Raw.IsNull() && req.State.Raw.Equal(req.Plan.Raw) {
+		return
+	}
+	var plan AccessPoliciesModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		tflog.Debug(ctx, "Access policies plan is not fully known, skipping conflict analysis")
+		return
+	}
+
+	names := sortedNames(plan.Policies)
+	models := make([]AccessPolicyModelV11, len(names))
+	for i, name := range names {
+		models[i] = entryToModel(name, plan.Policies[name])
+	}
+	for i := range models {
+		addConflictWarnings(&resp.Diagnostics, models[i], models[i+1:])
+	}
+
+	if !kept || len(unmanaged) == 0 {
+		return
+	}
+	existing, _, err := listCachedPolicies(r.data)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list access policies, skipping conflict analysis: %s", err))
+		return
+	}
+	var undeclared []AccessPolicyModelV11
+	for _, name := range unmanaged {
+		if json, ok := existing[name]; ok {
+			undeclared = append(undeclared, toModel(json))
+		}
+	}
+	for _, model := range models {
+		addConflictWarnings(&resp.Diagnostics, model, undeclared)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComparePolicies(t *testing.T) {
+	keep := "keep"
+	anyone := &RequestorModelV5{Type: "any"}
+	eng := &RequestorModelV5{Type: "group", Groups: []GroupModelV1{group("1", "Eng")}, Effect: &keep}
+	engOps := &RequestorModelV5{Type: "group", Groups: []GroupModelV1{group("2", "Ops"), group("1", "Eng")}, Effect: &keep}
+	sales := &RequestorModelV5{Type: "group", Groups: []GroupModelV1{group("3", "Sales")}, Effect: &keep}
+	profile := func(attributes map[string][]string) *RequestorModelV5 {
+		return &RequestorModelV5{Type: "profile", Profile: &RequestorProfileModel{Directory: "okta", Attributes: attributes}, Effect: &keep}
+	}
+	engineering := profile(map[string][]string{"department": {"Engineering", "SRE"}})
+	fullTimeSre := profile(map[string][]string{"department": {"SRE"}, "employeeType": {"Full-time"}})
+	salesProfile := profile(map[string][]string{"department": {"Sales"}})
+	anyResource := &ResourceModel{Type: "any"}
+	aws := &ResourceModel{Type: "integration", Service: ptr("aws")}
+	awsRole := &ResourceModel{Type: "integration", Service: ptr("aws"), AccessType: ptr("role")}
+	gcloud := &ResourceModel{Type: "integration", Service: ptr("gcloud")}
+	p0 := []ApprovalModelV5{{Type: "p0"}}
+	auto := []ApprovalModelV5{{Type: "auto", Integration: ptr("pagerduty")}}
+	deny := []ApprovalModelV5{{Type: "deny"}}
+	policy := func(name string, requestor *RequestorModelV5, resource *ResourceModel, approval []ApprovalModelV5) AccessPolicyModelV11 {
+		return AccessPolicyModelV11{Name: ptr(name), Requestor: requestor, Resource: resource, Approval: approval}
+	}
+
+	cases := []struct {
+		name string
+		a, b AccessPolicyModelV11
+		want string
+	}{
+		{name: "broader deny shadows", a: policy("a", anyone, aws, deny), b: policy("b", eng, awsRole, p0), want: `Shadowed access policy: Access policy "b" never grants access: "a"`},
+		{name: "partial deny contradicts", a: policy("a", eng, aws, deny), b: policy("b", engOps, awsRole, p0), want: `Contradictory access policies: Access policy "a" denies some of the requests that "b" grants`},
+		{name: "same approval shadows", a: policy("a", eng, awsRole, p0), b: policy("b", engOps, anyResource, p0), want: `Shadowed access policy: Access policy "a" has no effect: "b"`},
+		{name: "same scope, different approval", a: policy("a", engOps, aws, p0), b: policy("b", engOps, aws, auto), want: "Overlapping access policies"},
+		{name: "duplicate", a: policy("a", engOps, aws, p0), b: policy("b", engOps, aws, p0), want: "Duplicate access policies"},
+		{name: "narrower refinement is fine", a: policy("a", anyone, aws, p0), b: policy("b", eng, awsRole, auto)},
+		{name: "disjoint requestors", a: policy("a", eng, aws, deny), b: policy("b", sales, aws, p0)},
+		{name: "narrower profile shadowed", a: policy("a", engineering, aws, deny), b: policy("b", fullTimeSre, awsRole, p0), want: `Shadowed access policy: Access policy "b" never grants access: "a"`},
+		{name: "disjoint profiles", a: policy("a", engineering, aws, deny), b: policy("b", salesProfile, aws, p0)},
+		{name: "disjoint resources", a: policy("a", anyone, gcloud, deny), b: policy("b", eng, aws, p0)},
+		{name: "disabled", a: AccessPolicyModelV11{Name: ptr("a"), Disabled: ptr(true), Requestor: anyone, Resource: aws, Approval: deny}, b: policy("b", eng, aws, p0)},
+		{
+			name: "break-glass route",
+			a:    policy("a", eng, aws, p0),
+			b:    AccessPolicyModelV11{Name: ptr("b"), Requestor: eng, Resource: aws, Approval: auto, BreakGlass: &BreakGlassModel{}},
+		},
+		{
+			name: "different schedules",
+			a:    AccessPolicyModelV11{Name: ptr("a"), Requestor: anyone, Resource: aws, Approval: deny, Schedule: &ScheduleModel{Timezone: "UTC", Days: []string{"saturday"}}},
+			b:    AccessPolicyModelV11{Name: ptr("b"), Requestor: anyone, Resource: aws, Approval: p0, Schedule: &ScheduleModel{Timezone: "UTC", Days: []string{"monday"}}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			conflicts := comparePolicies(c.a, c.b)
+			if c.want == "" {
+				if len(conflicts) != 0 {
+					t.Errorf("conflicts = %+v; want none", conflicts)
+				}
+				return
+			}
+			if len(conflicts) != 1 || !strings.HasPrefix(conflicts[0].Summary+": "+conflicts[0].Detail, c.want) {
+				t.Errorf("conflicts = %+v; want one starting with %q", conflicts, c.want)
+			}
+			// The analysis doesn't depend on the order of the pair.
+			if reversed := comparePolicies(c.b, c.a); len(reversed) != 1 || reversed[0].Summary != conflicts[0].Summary {
+				t.Errorf("reversed conflicts = %+v; want %+v", reversed, conflicts)
+			}
+		})
+	}
+}
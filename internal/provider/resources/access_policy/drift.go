@@ -0,0 +1,67 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// driftedAttributes returns the configurable attributes of current, as read
+// from P0, that differ from prior, the policy as Terraform last saw it.
+// Semantically equal values (see semantic.go) are not changes.
+func driftedAttributes(prior AccessPolicyModelV11, current AccessPolicyModelV11) []string {
+	var drifted []string
+	if isDisabled(prior) != isDisabled(current) {
+		drifted = append(drifted, "disabled")
+	}
+	if !requestorsEqual(prior.Requestor, current.Requestor) {
+		drifted = append(drifted, "requestor")
+	}
+	if !reflect.DeepEqual(prior.Resource, current.Resource) {
+		drifted = append(drifted, "resource")
+	}
+	if !approvalsEqual(prior.Approval, current.Approval) {
+		drifted = append(drifted, "approval")
+	}
+	if !reflect.DeepEqual(prior.Schedule, current.Schedule) {
+		drifted = append(drifted, "schedule")
+	}
+	if !reflect.DeepEqual(prior.BreakGlass, current.BreakGlass) {
+		drifted = append(drifted, "break_glass")
+	}
+	if !reflect.DeepEqual(prior.Notifications, current.Notifications) {
+		drifted = append(drifted, "notifications")
+	}
+	return drifted
+}
+
+// driftWarning describes a change to a policy made outside of Terraform, i.e.
+// one that P0 recorded after the change Terraform last saw. It returns false
+// if there is no such change, or if the prior state is too incomplete (e.g.,
+// while importing) to tell.
+func driftWarning(prior AccessPolicyModelV11, current AccessPolicyModelV11) (string, string, bool) {
+	if prior.Requestor == nil || prior.LastModifiedAt.IsNull() || prior.LastModifiedAt.IsUnknown() || current.LastModifiedAt.IsNull() ||
+		prior.LastModifiedAt.Equal(current.LastModifiedAt) {
+		return "", "", false
+	}
+	drifted := driftedAttributes(prior, current)
+	if len(drifted) == 0 {
+		return "", "", false
+	}
+
+	who := "someone"
+	if !current.LastModifiedBy.IsNull() && current.LastModifiedBy.ValueString() != "" {
+		who = current.LastModifiedBy.ValueString()
+	}
+	detail := fmt.Sprintf("Access policy %q was changed outside of Terraform by %s at %s; changed: %s.",
+		policyName(current), who, current.LastModifiedAt.ValueString(), strings.Join(drifted, ", "))
+	if isDisabled(current) && !isDisabled(prior) {
+		detail += " Unless the configuration is updated to match, applying it will re-enable the policy."
+	} else {
+		detail += " Unless the configuration is updated to match, applying it will revert these changes."
+	}
+	return "Access policy changed outside of Terraform", detail, true
+}
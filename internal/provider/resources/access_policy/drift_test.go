@@ -0,0 +1,74 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDriftWarning(t *testing.T) {
+	prior := AccessPolicyModelV11{
+		Name:           strPtr("eng"),
+		Requestor:      &RequestorModelV5{Type: "any"},
+		Resource:       &ResourceModel{Type: "any"},
+		Approval:       []ApprovalModelV5{{Type: "p0"}},
+		LastModifiedBy: types.StringValue("terraform@example.com"),
+		LastModifiedAt: types.StringValue("2026-01-01T00:00:00Z"),
+	}
+	modified := func(edit func(*AccessPolicyModelV11)) AccessPolicyModelV11 {
+		current := prior
+		current.LastModifiedBy = types.StringValue("alice@example.com")
+		current.LastModifiedAt = types.StringValue("2026-02-01T00:00:00Z")
+		edit(&current)
+		return current
+	}
+
+	cases := []struct {
+		name       string
+		prior      AccessPolicyModelV11
+		current    AccessPolicyModelV11
+		wantDetail []string
+	}{
+		{name: "unchanged", prior: prior, current: prior},
+		{name: "modified without changes", prior: prior, current: modified(func(*AccessPolicyModelV11) {})},
+		{
+			name:       "disabled",
+			prior:      prior,
+			current:    modified(func(m *AccessPolicyModelV11) { m.Disabled = ptr(true) }),
+			wantDetail: []string{"alice@example.com", "2026-02-01T00:00:00Z", "changed: disabled.", "re-enable"},
+		},
+		{
+			name:       "approval and schedule",
+			prior:      prior,
+			current:    modified(func(m *AccessPolicyModelV11) { m.Approval = nil; m.Schedule = &ScheduleModel{Timezone: "UTC"} }),
+			wantDetail: []string{"changed: approval, schedule.", "revert"},
+		},
+		{
+			name:    "upgraded state",
+			prior:   AccessPolicyModelV11{Name: prior.Name, Requestor: prior.Requestor, Resource: prior.Resource, Approval: prior.Approval},
+			current: modified(func(m *AccessPolicyModelV11) { m.Disabled = ptr(true) }),
+		},
+		{
+			name:    "importing",
+			prior:   AccessPolicyModelV11{Name: prior.Name, LastModifiedAt: types.StringNull()},
+			current: modified(func(m *AccessPolicyModelV11) { m.Disabled = ptr(true) }),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, detail, ok := driftWarning(c.prior, c.current)
+			if ok != (c.wantDetail != nil) {
+				t.Fatalf("driftWarning ok = %v; want %v (detail %q)", ok, c.wantDetail != nil, detail)
+			}
+			for _, want := range c.wantDetail {
+				if !strings.Contains(detail, want) {
+					t.Errorf("detail %q does not contain %q", detail, want)
+				}
+			}
+		})
+	}
+}
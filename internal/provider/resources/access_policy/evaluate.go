@@ -0,0 +1,521 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p0-security/terraform-provider-p0/internal/provider/resources/settings"
+)
+
+var _ function.Function = &EvaluatePolicyFunction{}
+
+// EvaluationAgentModel describes the agent behind a hypothetical agentic
+// request.
+type EvaluationAgentModel struct {
+	ClientId    *string  `tfsdk:"client_id"`
+	Owner       *string  `tfsdk:"owner"`
+	OwnerGroups []string `tfsdk:"owner_groups"`
+	ProviderId  *string  `tfsdk:"provider_id"`
+	Subject     *string  `tfsdk:"subject"`
+}
+
+// EvaluationRequestModel is a hypothetical access request evaluated against a
+// policy. For agentic requests, Email and Groups describe the human user (if
+// any) behind the agent. Since evaluation is offline, the requestor's
+// principal set memberships and profile are given rather than resolved.
+type EvaluationRequestModel struct {
+	Email         *string               `tfsdk:"email"`
+	Groups        []string              `tfsdk:"groups"`
+	PrincipalSets []string              `tfsdk:"principal_sets"`
+	Profile       map[string]string     `tfsdk:"profile"`
+	Agent         *EvaluationAgentModel `tfsdk:"agent"`
+	Service       *string               `tfsdk:"service"`
+	AccessType    *string               `tfsdk:"access_type"`
+	Resource      map[string]string     `tfsdk:"resource"`
+	Time          *string               `tfsdk:"time"`
+	Reason        *string               `tfsdk:"reason"`
+	Duration      *DurationModel        `tfsdk:"duration"`
+}
+
+// EvaluationResultModel is the result of evaluating a request against a policy.
+type EvaluationResultModel struct {
+	Matches  bool              `tfsdk:"matches"`
+	Denied   bool              `tfsdk:"denied"`
+	Reason   string            `tfsdk:"reason"`
+	Approval []ApprovalModelV5 `tfsdk:"approval"`
+}
+
+var (
+	policyObjectType = newAccessPolicySchema(currentSchemaVersion).Type().(types.ObjectType)
+
+	evaluationAgentObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"client_id":    types.StringType,
+		"owner":        types.StringType,
+		"owner_groups": types.ListType{ElemType: types.StringType},
+		"provider_id":  types.StringType,
+		"subject":      types.StringType,
+	}}
+	evaluationRequestObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"email":          types.StringType,
+		"groups":         types.ListType{ElemType: types.StringType},
+		"principal_sets": types.ListType{ElemType: types.StringType},
+		"profile":        types.MapType{ElemType: types.StringType},
+		"agent":          evaluationAgentObjectType,
+		"service":        types.StringType,
+		"access_type":    types.StringType,
+		"resource":       types.MapType{ElemType: types.StringType},
+		"time":           types.StringType,
+		"reason":         types.StringType,
+		"duration":       types.ObjectType{AttrTypes: map[string]attr.Type{"time": types.Int64Type, "unit": types.StringType}},
+	}}
+	evaluationResultAttrTypes = map[string]attr.Type{
+		"matches":  types.BoolType,
+		"denied":   types.BoolType,
+		"reason":   types.StringType,
+		"approval": types.ListType{ElemType: approvalObjectType},
+	}
+)
+
+// equalFold reports whether an optional string equals want, ignoring case as
+// P0 does for email addresses.
+func equalFold(value *string, want *string) bool {
+	return value != nil && want != nil && strings.EqualFold(*value, *want)
+}
+
+// matchesGroups applies a groups+effect rule to a set of group identifiers:
+// 'keep' matches members of any group, 'remove' matches non-members.
+func matchesGroups(groups []GroupModelV1, effect *string, memberOf []string) bool {
+	member := slices.ContainsFunc(groups, func(g GroupModelV1) bool {
+		return g.Id != nil && slices.Contains(memberOf, *g.Id)
+	})
+	if effect != nil && *effect == "remove" {
+		return !member
+	}
+	return member
+}
+
+// matchesProfile applies a profile+effect rule to a requestor's profile
+// attributes: 'keep' matches profiles with one of the listed values for every
+// attribute, 'remove' matches all other profiles.
+func matchesProfile(rule *RequestorProfileModel, effect *string, profile map[string]string) bool {
+	matched := rule != nil
+	if rule != nil {
+		for name, values := range rule.Attributes {
+			value, ok := profile[name]
+			if !ok || !slices.Contains(values, value) {
+				matched = false
+				break
+			}
+		}
+	}
+	if effect != nil && *effect == "remove" {
+		return !matched
+	}
+	return matched
+}
+
+// matchesPattern reports whether value matches the unanchored pattern.
+func matchesPattern(pattern string, value string) (bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return re.MatchString(value), nil
+}
+
+// matchRequestor returns the reason the request's requestor does not match
+// the rule, or "" if it does.
+func matchRequestor(rule *RequestorModelV5, request EvaluationRequestModel) (string, error) {
+	switch rule.Type {
+	case "any":
+		return "", nil
+	case "user":
+		if !equalFold(request.Email, rule.Uid) {
+			return "requestor is not the policy's user", nil
+		}
+	case "group":
+		if !matchesGroups(rule.Groups, rule.Effect, request.Groups) {
+			return "requestor's groups do not match the policy's groups", nil
+		}
+	case "principal-set":
+		if rule.PrincipalSet == nil || !slices.Contains(request.PrincipalSets, *rule.PrincipalSet) {
+			return "requestor is not a member of the policy's principal set", nil
+		}
+	case "profile":
+		if !matchesProfile(rule.Profile, rule.Effect, request.Profile) {
+			return "requestor's profile does not match the policy's profile attributes", nil
+		}
+	case "agentic":
+		if request.Agent == nil {
+			return "request is not from an agent", nil
+		}
+		if reason, err := matchAgent(rule.Agent, *request.Agent); reason != "" || err != nil {
+			return reason, err
+		}
+		return matchAgenticUser(rule.User, request), nil
+	default:
+		return "", fmt.Errorf("unsupported requestor type %q", rule.Type)
+	}
+	return "", nil
+}
+
+func matchAgent(rule *AgentModel, agent EvaluationAgentModel) (string, error) {
+	if rule == nil {
+		return "", nil
+	}
+	switch rule.Type {
+	case "any":
+		return "", nil
+	case "agent-client", "mcp-client":
+		if agent.ClientId == nil || rule.ClientId == nil || *agent.ClientId != *rule.ClientId {
+			return "agent's client does not match the policy's client", nil
+		}
+	case "agent-owner":
+		if !equalFold(agent.Owner, rule.Owner) {
+			return "agent's owner is not the policy's owner", nil
+		}
+	case "owner-group":
+		if !matchesGroups(rule.Groups, rule.Effect, agent.OwnerGroups) {
+			return "agent owner's groups do not match the policy's groups", nil
+		}
+	case "provider":
+		if agent.ProviderId == nil || rule.ProviderId == nil || *agent.ProviderId != *rule.ProviderId {
+			return "agent's identity provider does not match the policy's provider", nil
+		}
+		if rule.SubjectPattern != nil {
+			subject := ""
+			if agent.Subject != nil {
+				subject = *agent.Subject
+			}
+			matched, err := matchesPattern(*rule.SubjectPattern, subject)
+			if err != nil || !matched {
+				return "agent's subject does not match the policy's subject pattern", err
+			}
+		}
+	default:
+		return "", fmt.Errorf("unsupported agent type %q", rule.Type)
+	}
+	return "", nil
+}
+
+func matchAgenticUser(rule *AgenticUserModel, request EvaluationRequestModel) string {
+	if rule == nil {
+		return ""
+	}
+	headless := request.Email == nil || *request.Email == ""
+	switch rule.Type {
+	case "none":
+		if !headless {
+			return "agent session has a human user, but the policy only matches headless sessions"
+		}
+	case "user":
+		if !equalFold(request.Email, rule.Uid) {
+			return "agent's user is not the policy's user"
+		}
+	case "group":
+		if headless || !matchesGroups(rule.Groups, rule.Effect, request.Groups) {
+			return "agent user's groups do not match the policy's groups"
+		}
+	}
+	return ""
+}
+
+// matchResource returns the reason the requested resource does not match the
+// rule, or "" if it does.
+func matchResource(rule *ResourceModel, request EvaluationRequestModel) (string, error) {
+	if rule == nil || rule.Type == "any" {
+		return "", nil
+	}
+	if rule.Type != "integration" {
+		return "", fmt.Errorf("unsupported resource type %q", rule.Type)
+	}
+	if request.Service == nil || rule.Service == nil || *request.Service != *rule.Service {
+		return "requested service does not match the policy's service", nil
+	}
+	if rule.AccessType != nil && *rule.AccessType != "any" {
+		if request.AccessType == nil || *request.AccessType != *rule.AccessType {
+			return "requested access type does not match the policy's access type", nil
+		}
+	}
+	if rule.Filters == nil {
+		return "", nil
+	}
+	keys := make([]string, 0, len(*rule.Filters))
+	for key := range *rule.Filters {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		matched, err := matchFilter((*rule.Filters)[key], request.Resource, key)
+		if err != nil {
+			return "", fmt.Errorf("filter %q: %w", key, err)
+		}
+		if !matched {
+			return fmt.Sprintf("requested resource does not match the policy's %q filter", key), nil
+		}
+	}
+	return "", nil
+}
+
+// matchFilter applies a single resource filter to the requested resource's
+// attributes.
+func matchFilter(filter ResourceFilterModel, resource map[string]string, key string) (bool, error) {
+	value, present := resource[key]
+	if filter.Effect == "removeAll" {
+		return !present, nil
+	}
+
+	var matched bool
+	switch {
+	case !present:
+		matched = false
+	case filter.Pattern != nil:
+		var err error
+		if matched, err = matchesPattern(*filter.Pattern, value); err != nil {
+			return false, err
+		}
+	case filter.Key != nil:
+		matched = value == *filter.Key
+	case filter.Value != nil:
+		matched = value == strconv.FormatBool(*filter.Value)
+	}
+
+	switch filter.Effect {
+	case "keep":
+		return matched, nil
+	case "remove":
+		return !matched, nil
+	}
+	return false, fmt.Errorf("unsupported filter effect %q", filter.Effect)
+}
+
+// parseRequestTime parses the request's time, if any.
+func parseRequestTime(at *string) (*time.Time, error) {
+	if at == nil {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *at)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request time %q; expected an RFC 3339 timestamp, e.g. \"2025-01-06T09:30:00Z\"", *at)
+	}
+	return &parsed, nil
+}
+
+// requestSeconds returns the request's duration in seconds, if any.
+func requestSeconds(duration *DurationModel) (*int64, error) {
+	if duration == nil {
+		return nil, nil
+	}
+	seconds, ok := settings.DurationSeconds(duration.Time, duration.Unit)
+	if !ok || duration.Time < 1 {
+		return nil, fmt.Errorf("invalid request duration %d%s; use provider::p0::duration, e.g. provider::p0::duration(\"4h\")", duration.Time, duration.Unit)
+	}
+	return &seconds, nil
+}
+
+// validateRequest reports whether the request itself is invalid, so that its
+// errors are told apart from the policy's.
+func validateRequest(request EvaluationRequestModel) error {
+	if _, err := parseRequestTime(request.Time); err != nil {
+		return err
+	}
+	_, err := requestSeconds(request.Duration)
+	return err
+}
+
+// matchSchedule checks the request's time, if any, against the policy's
+// schedule.
+func matchSchedule(schedule *ScheduleModel, at *string) (string, error) {
+	if schedule == nil {
+		return "", nil
+	}
+	parsed, err := parseRequestTime(at)
+	if err != nil || parsed == nil {
+		return "", err
+	}
+	active, err := scheduleActive(*schedule, *parsed)
+	if err != nil || active {
+		return "", err
+	}
+	return fmt.Sprintf("request time is outside of the policy's schedule (%s)", describeSchedule(*schedule)), nil
+}
+
+// approvalApplies reports whether the approval rule's request constraints allow
+// the request: its reason, and its duration, if any. A 'deny' rule always
+// applies.
+func approvalApplies(rule ApprovalModelV5, request EvaluationRequestModel) (bool, error) {
+	options := rule.Options
+	if rule.Type == "deny" || options == nil {
+		return true, nil
+	}
+	reason := ""
+	if request.Reason != nil {
+		reason = *request.Reason
+	}
+	if options.RequireReason != nil && *options.RequireReason && reason == "" {
+		return false, nil
+	}
+	if options.ReasonPattern != nil {
+		matched, err := matchesPattern(*options.ReasonPattern, reason)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	seconds, err := requestSeconds(request.Duration)
+	if err != nil {
+		return false, err
+	}
+	if options.RequireDuration != nil && *options.RequireDuration && seconds == nil {
+		return false, nil
+	}
+	if options.MaxDuration != nil && seconds != nil {
+		maxSeconds, ok := settings.DurationSeconds(options.MaxDuration.Time, options.MaxDuration.Unit)
+		if !ok {
+			return false, fmt.Errorf("unsupported max_duration unit %q", options.MaxDuration.Unit)
+		}
+		if *seconds > maxSeconds {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// evaluatePolicy determines whether a hypothetical request matches policy,
+// and, if so, which approval rules apply. The request must be valid (see
+// validateRequest), so that errors are the policy's.
+func evaluatePolicy(policy AccessPolicyModelV11, request EvaluationRequestModel) (EvaluationResultModel, error) {
+	result := EvaluationResultModel{Approval: []ApprovalModelV5{}}
+	if policy.Disabled != nil && *policy.Disabled {
+		result.Reason = "policy is disabled"
+		return result, nil
+	}
+	if policy.Requestor != nil {
+		reason, err := matchRequestor(policy.Requestor, request)
+		if err != nil || reason != "" {
+			result.Reason = reason
+			return result, err
+		}
+	}
+	reason, err := matchResource(policy.Resource, request)
+	if err != nil || reason != "" {
+		result.Reason = reason
+		return result, err
+	}
+	reason, err = matchSchedule(policy.Schedule, request.Time)
+	if err != nil || reason != "" {
+		result.Reason = reason
+		return result, err
+	}
+
+	result.Matches = true
+	for _, rule := range policy.Approval {
+		applies, err := approvalApplies(rule, request)
+		if err != nil {
+			return result, err
+		}
+		if applies {
+			result.Approval = append(result.Approval, rule)
+		}
+	}
+	// Access is disallowed if no approval rule applies, or if any 'deny' rule
+	// does.
+	result.Denied = len(result.Approval) == 0 || slices.ContainsFunc(result.Approval, func(a ApprovalModelV5) bool {
+		return a.Type == "deny"
+	})
+	return result, nil
+}
+
+func NewEvaluatePolicyFunction() function.Function {
+	return &EvaluatePolicyFunction{}
+}
+
+// EvaluatePolicyFunction evaluates a hypothetical request against an access
+// policy, offline.
+type EvaluatePolicyFunction struct{}
+
+func (f *EvaluatePolicyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "evaluate_policy"
+}
+
+func (f *EvaluatePolicyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Evaluates a hypothetical access request against an access policy",
+		MarkdownDescription: `Determines, without contacting P0, whether a hypothetical access request matches an access policy, and which
+approval rules then apply. Use it to test policy modules with ` + "`terraform test`" + `.
+
+Returns an object with attributes:
+    - ` + "`matches`" + `: Whether the request matches the policy's requestor, resource, and schedule
+    - ` + "`denied`" + `: Whether a matching request is always denied (a 'deny' approval rule, or no approval rule that applies)
+    - ` + "`reason`" + `: If the request does not match, why not
+    - ` + "`approval`" + `: The policy's approval rules that apply to the request, if it matches; otherwise empty. A rule applies unless
+      the request's ` + "`reason`" + ` or ` + "`duration`" + ` fails its ` + "`options`" + ` (` + "`require_reason`" + `, ` + "`reason_pattern`" + `, ` + "`require_duration`" + `,
+      or ` + "`max_duration`" + `).
+
+This is a model of P0's matching rules for a single policy; it does not consider other policies in the
+organization.`,
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "policy",
+				MarkdownDescription: "The access policy: a `p0_access_policy` resource, or an object of the same shape. Omitted attributes are null.",
+			},
+			function.DynamicParameter{
+				Name: "request",
+				MarkdownDescription: `The hypothetical request, an object with the optional attributes:
+    - ` + "`email`" + ` (String): The requestor's email address; for agentic requests, that of the human behind the agent, if any
+    - ` + "`groups`" + ` (List of String): Identifiers of the directory groups the requestor is a member of
+    - ` + "`principal_sets`" + ` (List of String): Names of the principal sets the requestor is a member of
+    - ` + "`profile`" + ` (Map of String): The requestor's directory profile attributes, e.g. ` + "`{ department = \"Engineering\" }`" + `
+    - ` + "`agent`" + ` (Object): For agentic requests, the agent's ` + "`client_id`" + `, ` + "`owner`" + `, ` + "`owner_groups`" + `, ` + "`provider_id`" + `, and ` + "`subject`" + `
+    - ` + "`service`" + ` (String): The requested integration, e.g. 'aws'
+    - ` + "`access_type`" + ` (String): The requested access type
+    - ` + "`resource`" + ` (Map of String): The requested resource's attributes, keyed by filter name
+    - ` + "`time`" + ` (String): When the request is made, as an RFC 3339 timestamp; if omitted, the policy's ` + "`schedule`" + ` is not checked
+    - ` + "`reason`" + ` (String): The request's reason
+    - ` + "`duration`" + ` (Object): The requested access duration, e.g. ` + "`provider::p0::duration(\"4h\")`" + ``,
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: evaluationResultAttrTypes},
+	}
+}
+
+func (f *EvaluatePolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var policyArg, requestArg types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &policyArg, &requestArg)
+	if resp.Error != nil {
+		return
+	}
+
+	var policy AccessPolicyModelV11
+	resp.Error = decodeArgument(ctx, 0, policyArg, policyObjectType, &policy)
+	if resp.Error != nil {
+		return
+	}
+	var request EvaluationRequestModel
+	resp.Error = decodeArgument(ctx, 1, requestArg, evaluationRequestObjectType, &request)
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateRequest(request); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	result, err := evaluatePolicy(policy, request)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func groupRule(effect string, ids ...string) ([]GroupModelV1, *string) {
+	groups := make([]GroupModelV1, 0, len(ids))
+	for _, id := range ids {
+		groups = append(groups, GroupModelV1{Directory: ptr("okta"), Id: ptr(id), Label: ptr(id)})
+	}
+	return groups, &effect
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	engGroups, keep := groupRule("keep", "eng")
+	_, remove := groupRule("remove", "eng")
+	awsProd := &ResourceModel{
+		Type:       "integration",
+		Service:    ptr("aws"),
+		AccessType: ptr("role"),
+		Filters: &map[string]ResourceFilterModel{
+			"account": {Effect: "keep", Pattern: ptr("^prod-")},
+			"secret":  {Effect: "removeAll"},
+		},
+	}
+	engineering := &RequestorProfileModel{Directory: "okta", Attributes: map[string][]string{
+		"department":   {"Engineering", "SRE"},
+		"employeeType": {"Full-time"},
+	}}
+	approval := []ApprovalModelV5{{Type: "p0"}}
+	deny := []ApprovalModelV5{{Type: "deny"}}
+
+	cases := []struct {
+		name        string
+		policy      AccessPolicyModelV11
+		request     EvaluationRequestModel
+		wantMatch   bool
+		wantDenied  bool
+		wantReason  string
+		wantErrText string
+	}{
+		{
+			name:      "any requestor, any resource",
+			policy:    AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "any"}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:   EvaluationRequestModel{Email: ptr("alice@example.com")},
+			wantMatch: true,
+		},
+		{
+			name:       "disabled",
+			policy:     AccessPolicyModelV11{Disabled: ptr(true), Requestor: &RequestorModelV5{Type: "any"}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			wantReason: "disabled",
+		},
+		{
+			name:      "user matches case-insensitively",
+			policy:    AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "user", Uid: ptr("Alice@example.com")}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:   EvaluationRequestModel{Email: ptr("alice@example.com")},
+			wantMatch: true,
+		},
+		{
+			name:       "group keep, not a member",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "group", Groups: engGroups, Effect: keep}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:    EvaluationRequestModel{Groups: []string{"sales"}},
+			wantReason: "groups",
+		},
+		{
+			name:      "group remove, not a member",
+			policy:    AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "group", Groups: engGroups, Effect: remove}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:   EvaluationRequestModel{Groups: []string{"sales"}},
+			wantMatch: true,
+		},
+		{
+			name:      "principal set member",
+			policy:    AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "principal-set", PrincipalSet: ptr("oncall")}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:   EvaluationRequestModel{PrincipalSets: []string{"admins", "oncall"}},
+			wantMatch: true,
+		},
+		{
+			name:       "principal set, not a member",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "principal-set", PrincipalSet: ptr("oncall")}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:    EvaluationRequestModel{PrincipalSets: []string{"admins"}},
+			wantReason: "principal set",
+		},
+		{
+			name:      "profile keep, matches",
+			policy:    AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "profile", Profile: engineering, Effect: keep}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:   EvaluationRequestModel{Profile: map[string]string{"department": "SRE", "employeeType": "Full-time", "city": "Boston"}},
+			wantMatch: true,
+		},
+		{
+			name:       "profile keep, one attribute does not match",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "profile", Profile: engineering, Effect: keep}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:    EvaluationRequestModel{Profile: map[string]string{"department": "SRE", "employeeType": "Contractor"}},
+			wantReason: "profile",
+		},
+		{
+			name:       "profile keep, attribute missing",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "profile", Profile: engineering, Effect: keep}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:    EvaluationRequestModel{Profile: map[string]string{"department": "SRE"}},
+			wantReason: "profile",
+		},
+		{
+			name:      "profile remove, does not match",
+			policy:    AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "profile", Profile: engineering, Effect: remove}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:   EvaluationRequestModel{Profile: map[string]string{"department": "Sales", "employeeType": "Full-time"}},
+			wantMatch: true,
+		},
+		{
+			name:      "filters match",
+			policy:    AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "any"}, Resource: awsProd, Approval: approval},
+			request:   EvaluationRequestModel{Service: ptr("aws"), AccessType: ptr("role"), Resource: map[string]string{"account": "prod-1"}},
+			wantMatch: true,
+		},
+		{
+			name:       "keep filter does not match",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "any"}, Resource: awsProd, Approval: approval},
+			request:    EvaluationRequestModel{Service: ptr("aws"), AccessType: ptr("role"), Resource: map[string]string{"account": "dev-1"}},
+			wantReason: `"account" filter`,
+		},
+		{
+			name:       "removeAll filter excludes present attribute",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "any"}, Resource: awsProd, Approval: approval},
+			request:    EvaluationRequestModel{Service: ptr("aws"), AccessType: ptr("role"), Resource: map[string]string{"account": "prod-1", "secret": "x"}},
+			wantReason: `"secret" filter`,
+		},
+		{
+			name:       "wrong service",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "any"}, Resource: awsProd, Approval: approval},
+			request:    EvaluationRequestModel{Service: ptr("gcloud")},
+			wantReason: "service",
+		},
+		{
+			name:       "deny approval",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "any"}, Resource: &ResourceModel{Type: "any"}, Approval: deny},
+			wantMatch:  true,
+			wantDenied: true,
+		},
+		{
+			name: "headless agent",
+			policy: AccessPolicyModelV11{Requestor: &RequestorModelV5{
+				Type:  "agentic",
+				Agent: &AgentModel{Type: "provider", ProviderId: ptr("github"), SubjectPattern: ptr("^repo:acme/")},
+				User:  &AgenticUserModel{Type: "none"},
+			}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:   EvaluationRequestModel{Agent: &EvaluationAgentModel{ProviderId: ptr("github"), Subject: ptr("repo:acme/api")}},
+			wantMatch: true,
+		},
+		{
+			name: "agent session with a human user",
+			policy: AccessPolicyModelV11{Requestor: &RequestorModelV5{
+				Type:  "agentic",
+				Agent: &AgentModel{Type: "any"},
+				User:  &AgenticUserModel{Type: "none"},
+			}, Resource: &ResourceModel{Type: "any"}, Approval: approval},
+			request:    EvaluationRequestModel{Email: ptr("alice@example.com"), Agent: &EvaluationAgentModel{}},
+			wantReason: "headless",
+		},
+		{
+			name:       "not an agent",
+			policy:     AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "agentic", Agent: &AgentModel{Type: "any"}, User: &AgenticUserModel{Type: "any"}}, Approval: approval},
+			request:    EvaluationRequestModel{Email: ptr("alice@example.com")},
+			wantReason: "not from an agent",
+		},
+		{
+			name: "outside of schedule",
+			policy: AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "any"}, Resource: &ResourceModel{Type: "any"}, Approval: approval, Schedule: &ScheduleModel{
+				Timezone: "UTC", TimeRanges: []TimeRangeModel{{Start: "09:00", End: "18:00"}},
+			}},
+			request:    EvaluationRequestModel{Time: ptr("2025-01-06T20:00:00Z")},
+			wantReason: "schedule",
+		},
+		{
+			name: "invalid pattern",
+			policy: AccessPolicyModelV11{Requestor: &RequestorModelV5{Type: "any"}, Resource: &ResourceModel{
+				Type: "integration", Service: ptr("aws"),
+				Filters: &map[string]ResourceFilterModel{"account": {Effect: "keep", Pattern: ptr("(")}},
+			}, Approval: approval},
+			request:     EvaluationRequestModel{Service: ptr("aws"), Resource: map[string]string{"account": "prod"}},
+			wantErrText: "invalid pattern",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := evaluatePolicy(c.policy, c.request)
+			if c.wantErrText != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErrText) {
+					t.Fatalf("error = %v; want it to contain %q", err, c.wantErrText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if result.Matches != c.wantMatch || result.Denied != c.wantDenied {
+				t.Errorf("matches, denied = %v, %v; want %v, %v (reason %q)", result.Matches, result.Denied, c.wantMatch, c.wantDenied, result.Reason)
+			}
+			if !strings.Contains(result.Reason, c.wantReason) {
+				t.Errorf("reason = %q; want it to contain %q", result.Reason, c.wantReason)
+			}
+			if !result.Matches && len(result.Approval) != 0 {
+				t.Errorf("approval = %v; want none for a non-matching request", result.Approval)
+			}
+		})
+	}
+}
+
+func TestEvaluatePolicyFunction(t *testing.T) {
+	policy := dynamicObject(map[string]attr.Value{
+		"name":      types.StringValue("eng"),
+		"requestor": dynamicObject(map[string]attr.Value{"type": types.StringValue("user"), "uid": types.StringValue("alice@example.com")}).UnderlyingValue(),
+		"resource":  dynamicObject(map[string]attr.Value{"type": types.StringValue("any")}).UnderlyingValue(),
+		"approval": types.TupleValueMust(
+			[]attr.Type{types.ObjectType{AttrTypes: map[string]attr.Type{"type": types.StringType, "stage": types.NumberType}}},
+			[]attr.Value{types.ObjectValueMust(
+				map[string]attr.Type{"type": types.StringType, "stage": types.NumberType},
+				map[string]attr.Value{"type": types.StringValue("auto"), "stage": types.NumberValue(big.NewFloat(1))},
+			)},
+		),
+	})
+	request := dynamicObject(map[string]attr.Value{"email": types.StringValue("alice@example.com")})
+
+	result, funcErr := runFunction(t, NewEvaluatePolicyFunction(), types.ObjectUnknown(evaluationResultAttrTypes), policy, request)
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr)
+	}
+	attributes := result.(types.Object).Attributes()
+	if !attributes["matches"].(types.Bool).ValueBool() || attributes["denied"].(types.Bool).ValueBool() {
+		t.Errorf("unexpected result: %v", result)
+	}
+	if len(attributes["approval"].(types.List).Elements()) != 1 {
+		t.Errorf("unexpected approval: %v", attributes["approval"])
+	}
+
+	bad := dynamicObject(map[string]attr.Value{"emails": types.StringValue("alice@example.com")})
+	if _, funcErr := runFunction(t, NewEvaluatePolicyFunction(), types.ObjectUnknown(evaluationResultAttrTypes), policy, bad); funcErr == nil || !strings.Contains(funcErr.Text, "unsupported attributes: emails") {
+		t.Errorf("error = %v; want an unsupported attribute error", funcErr)
+	}
+}
+
+// TestEvaluatePolicyApprovalFilter verifies that only the approval rules whose
+// reason and duration constraints the request meets are returned.
+func TestEvaluatePolicyApprovalFilter(t *testing.T) {
+	policy := AccessPolicyModelV11{
+		Requestor: &RequestorModelV5{Type: "any"},
+		Resource:  &ResourceModel{Type: "any"},
+		Approval: []ApprovalModelV5{
+			{Type: "auto", Integration: ptr("pagerduty"), Options: &ApprovalOptionsModelV2{MaxDuration: &DurationModel{Time: 4, Unit: "h"}}},
+			{Type: "p0", Options: &ApprovalOptionsModelV2{ReasonPattern: ptr(`^JIRA-\d+`)}},
+			{Type: "persistent", Options: &ApprovalOptionsModelV2{RequireDuration: ptr(true)}},
+			{Type: "group"},
+		},
+	}
+	cases := []struct {
+		name       string
+		request    EvaluationRequestModel
+		wantTypes  []string
+		wantDenied bool
+	}{
+		{name: "no reason or duration", wantTypes: []string{"auto", "group"}},
+		{
+			name:      "ticket reason and short duration",
+			request:   EvaluationRequestModel{Reason: ptr("JIRA-12"), Duration: &DurationModel{Time: 1, Unit: "h"}},
+			wantTypes: []string{"auto", "p0", "persistent", "group"},
+		},
+		{
+			name:      "long duration",
+			request:   EvaluationRequestModel{Duration: &DurationModel{Time: 1, Unit: "d"}},
+			wantTypes: []string{"persistent", "group"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := evaluatePolicy(policy, c.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var ruleTypes []string
+			for _, rule := range result.Approval {
+				ruleTypes = append(ruleTypes, rule.Type)
+			}
+			if strings.Join(ruleTypes, ",") != strings.Join(c.wantTypes, ",") || result.Denied != c.wantDenied {
+				t.Errorf("approval, denied = %v, %v; want %v, %v", ruleTypes, result.Denied, c.wantTypes, c.wantDenied)
+			}
+		})
+	}
+
+	onlyTicket := AccessPolicyModelV11{Requestor: policy.Requestor, Resource: policy.Resource, Approval: policy.Approval[1:2]}
+	if result, _ := evaluatePolicy(onlyTicket, EvaluationRequestModel{}); !result.Matches || !result.Denied {
+		t.Errorf("matches, denied = %v, %v; want a match denied for lack of an applicable rule", result.Matches, result.Denied)
+	}
+}
+
+// TestEvaluatePolicyFunctionErrors verifies that errors in the request are
+// reported against the request argument, and errors in the policy against the
+// policy argument.
+func TestEvaluatePolicyFunctionErrors(t *testing.T) {
+	policy := func(pattern string) types.Dynamic {
+		return dynamicObject(map[string]attr.Value{
+			"name":      types.StringValue("eng"),
+			"requestor": dynamicObject(map[string]attr.Value{"type": types.StringValue("any")}).UnderlyingValue(),
+			"resource": dynamicObject(map[string]attr.Value{
+				"type":    types.StringValue("integration"),
+				"service": types.StringValue("aws"),
+				"filters": dynamicObject(map[string]attr.Value{
+					"account": dynamicObject(map[string]attr.Value{"effect": types.StringValue("keep"), "pattern": types.StringValue(pattern)}).UnderlyingValue(),
+				}).UnderlyingValue(),
+			}).UnderlyingValue(),
+			"schedule": dynamicObject(map[string]attr.Value{
+				"timezone": types.StringValue("UTC"),
+				"time_ranges": types.TupleValueMust(
+					[]attr.Type{types.ObjectType{AttrTypes: map[string]attr.Type{"start": types.StringType, "end": types.StringType}}},
+					[]attr.Value{types.ObjectValueMust(
+						map[string]attr.Type{"start": types.StringType, "end": types.StringType},
+						map[string]attr.Value{"start": types.StringValue("09:00"), "end": types.StringValue("18:00")},
+					)},
+				),
+			}).UnderlyingValue(),
+		})
+	}
+	request := func(at string) types.Dynamic {
+		return dynamicObject(map[string]attr.Value{
+			"service":  types.StringValue("aws"),
+			"resource": dynamicObject(map[string]attr.Value{"account": types.StringValue("prod")}).UnderlyingValue(),
+			"time":     types.StringValue(at),
+		})
+	}
+	cases := []struct {
+		name         string
+		policy       types.Dynamic
+		request      types.Dynamic
+		wantArgument int64
+		wantText     string
+	}{
+		{name: "invalid time", policy: policy("^prod"), request: request("tomorrow"), wantArgument: 1, wantText: "invalid request time"},
+		{name: "invalid time and pattern", policy: policy("("), request: request("tomorrow"), wantArgument: 1, wantText: "invalid request time"},
+		{name: "invalid pattern", policy: policy("("), request: request("2025-01-06T10:00:00Z"), wantArgument: 0, wantText: "invalid pattern"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, funcErr := runFunction(t, NewEvaluatePolicyFunction(), types.ObjectUnknown(evaluationResultAttrTypes), c.policy, c.request)
+			if funcErr == nil || funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != c.wantArgument || !strings.Contains(funcErr.Text, c.wantText) {
+				t.Errorf("error = %+v; want argument %d: %q", funcErr, c.wantArgument, c.wantText)
+			}
+		})
+	}
+}
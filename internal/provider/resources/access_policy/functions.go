@@ -0,0 +1,451 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &requestorFunction{}
+var _ function.Function = &approvalFunction{}
+
+// The builder functions return objects typed exactly as p0_access_policy's
+// current `requestor` and `approval` attributes, so their results can be
+// assigned to those attributes directly. Types are derived from the schema so
+// they cannot drift from it.
+var (
+	requestorObjectType = requestorAttribute(currentSchemaVersion).GetType().(types.ObjectType)
+	approvalObjectType  = approvalAttribute(currentSchemaVersion).NestedObject.Type().(types.ObjectType)
+	agentObjectType     = requestorObjectType.AttrTypes["agent"].(types.ObjectType)
+	userObjectType      = requestorObjectType.AttrTypes["user"].(types.ObjectType)
+	groupObjectType     = requestorObjectType.AttrTypes["groups"].(GroupsType).ElemType.(types.ObjectType)
+)
+
+var groupsParameter = function.ListParameter{
+	Name:                "groups",
+	ElementType:         groupObjectType,
+	MarkdownDescription: "The directory groups, each an object with `directory`, `id`, and `label` attributes.",
+}
+
+var effectParameter = function.StringParameter{
+	Name: "effect",
+	MarkdownDescription: `The filter effect. One of:
+    - 'keep': Match members of any of the groups
+    - 'remove': Match users who are _not_ members of any of the groups`,
+}
+
+// requestorFunction is a provider function that builds a `requestor` object
+// from its arguments; each exported constructor fixes the requestor `type`.
+type requestorFunction struct {
+	name       string
+	definition function.Definition
+	// build constructs the requestor from the function's arguments.
+	build func(ctx context.Context, args function.ArgumentsData) (RequestorModelV5, *function.FuncError)
+}
+
+func (f *requestorFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *requestorFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = f.definition
+	resp.Definition.Return = function.ObjectReturn{AttributeTypes: requestorObjectType.AttrTypes}
+}
+
+func (f *requestorFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	requestor, funcErr := f.build(ctx, req.Arguments)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	resp.Error = validateRequestor(ctx, requestor)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, requestor))
+}
+
+func NewUserRequestorFunction() function.Function {
+	return &requestorFunction{
+		name: "user_requestor",
+		definition: function.Definition{
+			Summary:             "Builds an access-policy requestor that matches a single user",
+			MarkdownDescription: "Returns a `p0_access_policy` `requestor` object with 'type' 'user', matching only the user with the given email address.",
+			Parameters: []function.Parameter{
+				function.StringParameter{Name: "email", MarkdownDescription: "The user's email address."},
+			},
+		},
+		build: func(ctx context.Context, args function.ArgumentsData) (RequestorModelV5, *function.FuncError) {
+			var email string
+			if funcErr := args.Get(ctx, &email); funcErr != nil {
+				return RequestorModelV5{}, funcErr
+			}
+			if email == "" {
+				return RequestorModelV5{}, function.NewArgumentFuncError(0, "`email` must not be empty.")
+			}
+			return RequestorModelV5{Type: "user", Uid: &email}, nil
+		},
+	}
+}
+
+func NewGroupRequestorFunction() function.Function {
+	return &requestorFunction{
+		name: "group_requestor",
+		definition: function.Definition{
+			Summary:             "Builds an access-policy requestor that matches directory-group members",
+			MarkdownDescription: "Returns a `p0_access_policy` `requestor` object with 'type' 'group', matching (or, with effect 'remove', excluding) members of any of the given directory groups.",
+			Parameters:          []function.Parameter{groupsParameter, effectParameter},
+		},
+		build: func(ctx context.Context, args function.ArgumentsData) (RequestorModelV5, *function.FuncError) {
+			var groups []GroupModelV1
+			var effect string
+			if funcErr := args.Get(ctx, &groups, &effect); funcErr != nil {
+				return RequestorModelV5{}, funcErr
+			}
+			if funcErr := validateGroups(0, groups, effect, 1); funcErr != nil {
+				return RequestorModelV5{}, funcErr
+			}
+			return RequestorModelV5{Type: "group", Groups: groups, Effect: &effect}, nil
+		},
+	}
+}
+
+func NewAgenticRequestorFunction() function.Function {
+	return &requestorFunction{
+		name: "agentic_requestor",
+		definition: function.Definition{
+			Summary: "Builds an access-policy requestor that matches agent sessions",
+			MarkdownDescription: `Returns a ` + "`p0_access_policy` `requestor`" + ` object with 'type' 'agentic', matching agent sessions by the agent's
+identity and the human user (if any) behind it.
+
+Both arguments are objects that only need the attributes relevant to their 'type'; omitted attributes are set to null.
+For example, ` + "`{ type = \"agent-owner\", owner = \"alice@example.com\" }`" + ` and ` + "`{ type = \"none\" }`" + `.`,
+			Parameters: []function.Parameter{
+				function.DynamicParameter{
+					Name:                "agent",
+					MarkdownDescription: "The agent's identity; an object with the attributes of the requestor's `agent` attribute.",
+				},
+				function.DynamicParameter{
+					Name:                "user",
+					MarkdownDescription: "The human user (if any) behind the agent; an object with the attributes of the requestor's `user` attribute.",
+				},
+			},
+		},
+		build: func(ctx context.Context, args function.ArgumentsData) (RequestorModelV5, *function.FuncError) {
+			var agentArg, userArg types.Dynamic
+			if funcErr := args.Get(ctx, &agentArg, &userArg); funcErr != nil {
+				return RequestorModelV5{}, funcErr
+			}
+			var agent AgentModel
+			if funcErr := decodeArgument(ctx, 0, agentArg, agentObjectType, &agent); funcErr != nil {
+				return RequestorModelV5{}, funcErr
+			}
+			var user AgenticUserModel
+			if funcErr := decodeArgument(ctx, 1, userArg, userObjectType, &user); funcErr != nil {
+				return RequestorModelV5{}, funcErr
+			}
+			return RequestorModelV5{Type: "agentic", Agent: &agent, User: &user}, nil
+		},
+	}
+}
+
+// approvalFunction is a provider function that builds an `approval` object.
+type approvalFunction struct{}
+
+func NewGroupApprovalFunction() function.Function {
+	return &approvalFunction{}
+}
+
+func (f *approvalFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "group_approval"
+}
+
+func (f *approvalFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds an access-policy approval rule for directory-group approvers",
+		MarkdownDescription: `Returns a ` + "`p0_access_policy` `approval`" + ` list element with 'type' 'group', allowing any member of the given
+directory groups to approve access. To set approval ` + "`options`" + `, ` + "`merge`" + ` them into the result.`,
+		Parameters: []function.Parameter{groupsParameter, effectParameter},
+		Return:     function.ObjectReturn{AttributeTypes: approvalObjectType.AttrTypes},
+	}
+}
+
+func (f *approvalFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var groups []GroupModelV1
+	var effect string
+	resp.Error = req.Arguments.Get(ctx, &groups, &effect)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = validateGroups(0, groups, effect, 1)
+	if resp.Error != nil {
+		return
+	}
+
+	approval := ApprovalModelV5{Type: "group", Groups: groups, Effect: &effect}
+	resp.Error = validateObject(ctx, approvalObjectType, approval, path.Root("approval"), RequiredWhenType(approvalTypeRequirements))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, approval))
+}
+
+// validateGroups checks the arguments shared by the group builders, which the
+// schema validators cannot: an empty list is non-null, but never matches.
+func validateGroups(groupsArg int64, groups []GroupModelV1, effect string, effectArg int64) *function.FuncError {
+	if len(groups) == 0 {
+		return function.NewArgumentFuncError(groupsArg, "`groups` must contain at least one group.")
+	}
+	if effect != "keep" && effect != "remove" {
+		return function.NewArgumentFuncError(effectArg, fmt.Sprintf("`effect` must be one of \"keep\" or \"remove\", got %q.", effect))
+	}
+	return nil
+}
+
+// validateRequestor applies the same validators p0_access_policy applies to a
+// configured `requestor`, including its nested `agent` and `user`.
+func validateRequestor(ctx context.Context, requestor RequestorModelV5) *function.FuncError {
+	root := path.Root("requestor")
+	funcErr := validateObject(ctx, requestorObjectType, requestor, root, RequiredWhenType(requestorTypeRequirements), ExclusiveToType(requestorTypeExclusives))
+	if requestor.Agent != nil {
+		funcErr = function.ConcatFuncErrors(funcErr, validateObject(ctx, agentObjectType, *requestor.Agent, root.AtName("agent"),
+			RequiredWhenType(agentTypeRequirements), ExclusiveToType(agentTypeExclusives)))
+	}
+	if requestor.User != nil {
+		funcErr = function.ConcatFuncErrors(funcErr, validateObject(ctx, userObjectType, *requestor.User, root.AtName("user"),
+			RequiredWhenType(agenticUserTypeRequirements)))
+	}
+	return funcErr
+}
+
+// validateObject runs object validators against model, converted to
+// objectType, and reports any diagnostics as a function error.
+func validateObject(ctx context.Context, objectType types.ObjectType, model any, p path.Path, validators ...validator.Object) *function.FuncError {
+	value, diags := types.ObjectValueFrom(ctx, objectType.AttrTypes, model)
+	if diags.HasError() {
+		return function.FuncErrorFromDiags(ctx, diags)
+	}
+	for _, v := range validators {
+		resp := &validator.ObjectResponse{}
+		v.ValidateObject(ctx, validator.ObjectRequest{Path: p, ConfigValue: value}, resp)
+		diags.Append(resp.Diagnostics...)
+	}
+	if !diags.HasError() {
+		return nil
+	}
+	return function.NewFuncError(diagnosticsText(diags))
+}
+
+// diagnosticsText joins error diagnostics into a single message, prefixing
+// each with the attribute it refers to.
+func diagnosticsText(diags diag.Diagnostics) string {
+	var messages []string
+	for _, d := range diags.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			messages = append(messages, fmt.Sprintf("%s: %s", withPath.Path(), d.Detail()))
+			continue
+		}
+		messages = append(messages, d.Detail())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// reshape converts an argument value into the schema type want. Arguments
+// passed as dynamic values keep the types of their HCL literals: objects may
+// omit attributes (which become null) and lists arrive as tuples. Attributes
+// that want does not define are rejected, so typos fail instead of being
+// silently dropped.
+func reshape(value attr.Value, want attr.Type) (attr.Value, error) {
+	if dynamic, ok := value.(types.Dynamic); ok {
+		if dynamic.IsNull() || dynamic.IsUnderlyingValueNull() {
+			return nullValue(want), nil
+		}
+		value = dynamic.UnderlyingValue()
+	}
+	if value.IsNull() {
+		return nullValue(want), nil
+	}
+	if value.Type(context.Background()).Equal(want) {
+		return value, nil
+	}
+
+	switch t := want.(type) {
+	case types.ObjectType:
+		var attributes map[string]attr.Value
+		switch v := value.(type) {
+		case types.Object:
+			attributes = v.Attributes()
+		case types.Map:
+			attributes = v.Elements()
+		default:
+			return nil, fmt.Errorf("expected an object")
+		}
+		var unsupported []string
+		for name := range attributes {
+			if _, ok := t.AttrTypes[name]; !ok {
+				unsupported = append(unsupported, name)
+			}
+		}
+		if len(unsupported) > 0 {
+			sort.Strings(unsupported)
+			return nil, fmt.Errorf("unsupported attributes: %s", strings.Join(unsupported, ", "))
+		}
+		reshaped := make(map[string]attr.Value, len(t.AttrTypes))
+		for name, attrType := range t.AttrTypes {
+			element, ok := attributes[name]
+			if !ok {
+				reshaped[name] = nullValue(attrType)
+				continue
+			}
+			converted, err := reshape(element, attrType)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			reshaped[name] = converted
+		}
+		object, diags := types.ObjectValue(t.AttrTypes, reshaped)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diagnosticsText(diags))
+		}
+		return object, nil
+	case types.ListType:
+		var elements []attr.Value
+		switch v := value.(type) {
+		case types.Tuple:
+			elements = v.Elements()
+		case types.List:
+			elements = v.Elements()
+		case types.Set:
+			elements = v.Elements()
+		default:
+			return nil, fmt.Errorf("expected a list")
+		}
+		reshaped := make([]attr.Value, len(elements))
+		for i, element := range elements {
+			converted, err := reshape(element, t.ElemType)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			reshaped[i] = converted
+		}
+		list, diags := types.ListValue(t.ElemType, reshaped)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diagnosticsText(diags))
+		}
+		return list, nil
+	case types.MapType:
+		var elements map[string]attr.Value
+		switch v := value.(type) {
+		case types.Object:
+			elements = v.Attributes()
+		case types.Map:
+			elements = v.Elements()
+		default:
+			return nil, fmt.Errorf("expected a map")
+		}
+		reshaped := make(map[string]attr.Value, len(elements))
+		for key, element := range elements {
+			converted, err := reshape(element, t.ElemType)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			reshaped[key] = converted
+		}
+		m, diags := types.MapValue(t.ElemType, reshaped)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diagnosticsText(diags))
+		}
+		return m, nil
+	case basetypes.ListTypable:
+		// A custom list type (see semantic.go) is reshaped as its underlying list.
+		list, err := reshape(value, types.ListType{ElemType: t.(attr.TypeWithElementType).ElementType()})
+		if err != nil {
+			return nil, err
+		}
+		custom, diags := t.ValueFromList(context.Background(), list.(types.List))
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diagnosticsText(diags))
+		}
+		return custom, nil
+	case basetypes.MapTypable:
+		// A custom map type (see semantic.go) is reshaped as its underlying map.
+		m, err := reshape(value, types.MapType{ElemType: t.(attr.TypeWithElementType).ElementType()})
+		if err != nil {
+			return nil, err
+		}
+		custom, diags := t.ValueFromMap(context.Background(), m.(types.Map))
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diagnosticsText(diags))
+		}
+		return custom, nil
+	case basetypes.BoolType:
+		return nil, fmt.Errorf("expected a bool")
+	case basetypes.Int64Type:
+		number, ok := value.(types.Number)
+		if !ok || number.IsUnknown() {
+			return nil, fmt.Errorf("expected a number")
+		}
+		integer, accuracy := number.ValueBigFloat().Int64()
+		if accuracy != big.Exact {
+			return nil, fmt.Errorf("expected a whole number")
+		}
+		return types.Int64Value(integer), nil
+	default:
+		return nil, fmt.Errorf("expected a string")
+	}
+}
+
+// nullValue returns a null value of the given attribute type.
+func nullValue(attrType attr.Type) attr.Value {
+	switch t := attrType.(type) {
+	case types.ListType:
+		return types.ListNull(t.ElemType)
+	case types.ObjectType:
+		return types.ObjectNull(t.AttrTypes)
+	case types.MapType:
+		return types.MapNull(t.ElemType)
+	case basetypes.ListTypable:
+		value, _ := t.ValueFromList(context.Background(), types.ListNull(t.(attr.TypeWithElementType).ElementType()))
+		return value
+	case basetypes.MapTypable:
+		value, _ := t.ValueFromMap(context.Background(), types.MapNull(t.(attr.TypeWithElementType).ElementType()))
+		return value
+	case basetypes.BoolType:
+		return types.BoolNull()
+	case basetypes.Int64Type:
+		return types.Int64Null()
+	default:
+		return types.StringNull()
+	}
+}
+
+// decodeArgument reshapes a dynamic object argument into objectType and
+// decodes it into target, a model struct of that type.
+func decodeArgument(ctx context.Context, argument int64, value types.Dynamic, objectType types.ObjectType, target any) *function.FuncError {
+	reshaped, err := reshape(value, objectType)
+	if err != nil {
+		return function.NewArgumentFuncError(argument, err.Error())
+	}
+	object := reshaped.(types.Object)
+	if object.IsNull() {
+		return function.NewArgumentFuncError(argument, "must not be null")
+	}
+	diags := object.As(ctx, target, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return function.NewArgumentFuncError(argument, diagnosticsText(diags))
+	}
+	return nil
+}
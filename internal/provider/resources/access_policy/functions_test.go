@@ -0,0 +1,186 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// runFunction calls a provider function with the given arguments, returning
+// its result and error.
+func runFunction(t *testing.T, f function.Function, returnType attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	resp := function.RunResponse{Result: function.NewResultData(returnType)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func groupValue(id string) attr.Value {
+	return types.ObjectValueMust(groupObjectType.AttrTypes, map[string]attr.Value{
+		"directory": types.StringValue("okta"),
+		"id":        types.StringValue(id),
+		"label":     types.StringValue("Group " + id),
+	})
+}
+
+// dynamicObject builds an HCL-literal-like object, typed from its own values.
+func dynamicObject(attrs map[string]attr.Value) types.Dynamic {
+	attrTypes := map[string]attr.Type{}
+	for name, value := range attrs {
+		attrTypes[name] = value.Type(context.Background())
+	}
+	return types.DynamicValue(types.ObjectValueMust(attrTypes, attrs))
+}
+
+func decodeRequestor(t *testing.T, value attr.Value) RequestorModelV5 {
+	t.Helper()
+	var requestor RequestorModelV5
+	if diags := value.(types.Object).As(context.Background(), &requestor, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("failed to decode requestor: %v", diags)
+	}
+	return requestor
+}
+
+func TestUserRequestorFunction(t *testing.T) {
+	result, funcErr := runFunction(t, NewUserRequestorFunction(), types.ObjectUnknown(requestorObjectType.AttrTypes), types.StringValue("alice@example.com"))
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr)
+	}
+	requestor := decodeRequestor(t, result)
+	if requestor.Type != "user" || requestor.Uid == nil || *requestor.Uid != "alice@example.com" {
+		t.Errorf("unexpected requestor: %+v", requestor)
+	}
+
+	if _, funcErr := runFunction(t, NewUserRequestorFunction(), types.ObjectUnknown(requestorObjectType.AttrTypes), types.StringValue("")); funcErr == nil {
+		t.Error("expected an error for an empty email")
+	}
+}
+
+func TestGroupRequestorFunction(t *testing.T) {
+	groups := types.ListValueMust(groupObjectType, []attr.Value{groupValue("eng")})
+	empty := types.ListValueMust(groupObjectType, []attr.Value{})
+
+	cases := []struct {
+		name    string
+		groups  attr.Value
+		effect  string
+		wantErr string
+	}{
+		{name: "keep", groups: groups, effect: "keep"},
+		{name: "remove", groups: groups, effect: "remove"},
+		{name: "invalid effect", groups: groups, effect: "exclude", wantErr: "`effect` must be one of"},
+		{name: "no groups", groups: empty, effect: "keep", wantErr: "at least one group"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewGroupRequestorFunction(), types.ObjectUnknown(requestorObjectType.AttrTypes), c.groups, types.StringValue(c.effect))
+			if c.wantErr != "" {
+				if funcErr == nil || !strings.Contains(funcErr.Text, c.wantErr) {
+					t.Fatalf("error = %v; want it to contain %q", funcErr, c.wantErr)
+				}
+				return
+			}
+			if funcErr != nil {
+				t.Fatalf("unexpected error: %s", funcErr)
+			}
+			requestor := decodeRequestor(t, result)
+			if requestor.Type != "group" || len(requestor.Groups) != 1 || *requestor.Effect != c.effect {
+				t.Errorf("unexpected requestor: %+v", requestor)
+			}
+		})
+	}
+}
+
+func TestAgenticRequestorFunction(t *testing.T) {
+	tuple := types.TupleValueMust([]attr.Type{groupObjectType}, []attr.Value{groupValue("eng")})
+
+	cases := []struct {
+		name    string
+		agent   types.Dynamic
+		user    types.Dynamic
+		wantErr string
+	}{
+		{
+			name:  "agent owner with headless user",
+			agent: dynamicObject(map[string]attr.Value{"type": types.StringValue("agent-owner"), "owner": types.StringValue("alice@example.com")}),
+			user:  dynamicObject(map[string]attr.Value{"type": types.StringValue("none")}),
+		},
+		{
+			name: "owner group from a tuple literal",
+			agent: dynamicObject(map[string]attr.Value{
+				"type": types.StringValue("owner-group"), "groups": tuple, "effect": types.StringValue("keep"),
+			}),
+			user: dynamicObject(map[string]attr.Value{"type": types.StringValue("any")}),
+		},
+		{
+			name:    "missing required agent attribute",
+			agent:   dynamicObject(map[string]attr.Value{"type": types.StringValue("agent-owner")}),
+			user:    dynamicObject(map[string]attr.Value{"type": types.StringValue("any")}),
+			wantErr: "requestor.agent.owner",
+		},
+		{
+			name: "attribute exclusive to another agent type",
+			agent: dynamicObject(map[string]attr.Value{
+				"type": types.StringValue("any"), "groups": tuple, "effect": types.StringValue("keep"),
+			}),
+			user:    dynamicObject(map[string]attr.Value{"type": types.StringValue("any")}),
+			wantErr: "may only be used when `type` is \"owner-group\"",
+		},
+		{
+			name:    "missing required user attribute",
+			agent:   dynamicObject(map[string]attr.Value{"type": types.StringValue("any")}),
+			user:    dynamicObject(map[string]attr.Value{"type": types.StringValue("user")}),
+			wantErr: "requestor.user.uid",
+		},
+		{
+			name:    "unsupported attribute",
+			agent:   dynamicObject(map[string]attr.Value{"type": types.StringValue("agent-owner"), "owners": types.StringValue("alice@example.com")}),
+			user:    dynamicObject(map[string]attr.Value{"type": types.StringValue("any")}),
+			wantErr: "unsupported attributes: owners",
+		},
+		{
+			name:    "not an object",
+			agent:   types.DynamicValue(types.StringValue("any")),
+			user:    dynamicObject(map[string]attr.Value{"type": types.StringValue("any")}),
+			wantErr: "expected an object",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewAgenticRequestorFunction(), types.ObjectUnknown(requestorObjectType.AttrTypes), c.agent, c.user)
+			if c.wantErr != "" {
+				if funcErr == nil || !strings.Contains(funcErr.Text, c.wantErr) {
+					t.Fatalf("error = %v; want it to contain %q", funcErr, c.wantErr)
+				}
+				return
+			}
+			if funcErr != nil {
+				t.Fatalf("unexpected error: %s", funcErr)
+			}
+			requestor := decodeRequestor(t, result)
+			if requestor.Type != "agentic" || requestor.Agent == nil || requestor.User == nil {
+				t.Errorf("unexpected requestor: %+v", requestor)
+			}
+		})
+	}
+}
+
+func TestGroupApprovalFunction(t *testing.T) {
+	groups := types.ListValueMust(groupObjectType, []attr.Value{groupValue("security")})
+	result, funcErr := runFunction(t, NewGroupApprovalFunction(), types.ObjectUnknown(approvalObjectType.AttrTypes), groups, types.StringValue("keep"))
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr)
+	}
+	attributes := result.(types.Object).Attributes()
+	if attributes["type"].(types.String).ValueString() != "group" || !attributes["options"].IsNull() {
+		t.Errorf("unexpected approval: %v", result)
+	}
+}
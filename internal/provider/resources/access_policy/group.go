@@ -5,6 +5,7 @@ package accesspolicy
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 func prefixDescription(version int, description string) string {
@@ -40,6 +41,24 @@ func LabelAttribute(version int) schema.StringAttribute {
 	}
 }
 
+// groupNestedObject is the schema of each directory group in a `groups`
+// attribute.
+func groupNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"directory": DirectoryAttribute(1),
+			"id":        IdAttribute(1),
+			"label":     LabelAttribute(1),
+		},
+	}
+}
+
+// groupsType is the type of a `groups` attribute whose elements are
+// nestedObject.
+func groupsType(nestedObject schema.NestedAttributeObject) GroupsType {
+	return GroupsType{ListType: basetypes.ListType{ElemType: nestedObject.Type()}}
+}
+
 func AttachGroupAttributes(version int64, attributes map[string]schema.Attribute) map[string]schema.Attribute {
 	switch version {
 	case 0:
@@ -50,17 +69,17 @@ func AttachGroupAttributes(version int64, attributes map[string]schema.Attribute
 		}
 	default:
 		{
-			attributes["groups"] = schema.ListNestedAttribute{
+			groups := schema.ListNestedAttribute{
 				MarkdownDescription: `Required, and may only be used, if 'type' is 'group'. If the user is a member of any of these groups, the rule will match.`,
 				Optional:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"directory": DirectoryAttribute(1),
-						"id":        IdAttribute(1),
-						"label":     LabelAttribute(1),
-					},
-				},
+				NestedObject:        groupNestedObject(),
+			}
+			// Only the current schema is compared to the API's responses;
+			// earlier versions exist solely to decode prior state.
+			if version >= currentSchemaVersion {
+				groups.CustomType = groupsType(groups.NestedObject)
 			}
+			attributes["groups"] = groups
 		}
 	}
 	return attributes
@@ -0,0 +1,246 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+// AccessTypeJson is an access type supported by an installed integration,
+// along with the resource filter keys policies may use for it. Filters is nil
+// if P0 doesn't list them.
+type AccessTypeJson struct {
+	Key     string   `json:"key"`
+	Filters []string `json:"filters"`
+}
+
+// InstalledIntegrationJson is an integration installed in the P0 organization.
+// AccessTypes is nil if P0 doesn't list them.
+type InstalledIntegrationJson struct {
+	Key         string           `json:"key"`
+	AccessTypes []AccessTypeJson `json:"accessTypes"`
+}
+
+// InstalledIntegrationsJson is the response of `GET integrations`:
+//
+//	{"integrations": [{"key": "aws", "accessTypes": [{"key": "role", "filters": ["account"]}]}]}
+//
+// Each level is only validated against if it is present, so that a response
+// that omits access types or filters never rejects a valid policy.
+type InstalledIntegrationsJson struct {
+	Integrations []InstalledIntegrationJson `json:"integrations"`
+}
+
+// resourcePlanModel is the `resource` attribute with every value possibly
+// unknown, as it is during planning.
+type resourcePlanModel struct {
+	Type       types.String `tfsdk:"type"`
+	Service    types.String `tfsdk:"service"`
+	AccessType types.String `tfsdk:"access_type"`
+	Filters    types.Map    `tfsdk:"filters"`
+}
+
+// validateResourceIntegration checks a policy's `resource` against the
+// organization's installed integrations: `service` must be installed,
+// `access_type` must be supported by it, and each filter key must be supported
+// by the access type (or, if the access type is 'any', by any of the service's
+// access types). Unknown values are skipped.
+func validateResourceIntegration(resource resourcePlanModel, installed []InstalledIntegrationJson, resourcePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if resource.Type.ValueString() != "integration" || resource.Service.IsNull() || resource.Service.IsUnknown() {
+		return diags
+	}
+
+	service := resource.Service.ValueString()
+	index := slices.IndexFunc(installed, func(i InstalledIntegrationJson) bool { return i.Key == service })
+	if index < 0 {
+		keys := make([]string, 0, len(installed))
+		for _, integration := range installed {
+			keys = append(keys, integration.Key)
+		}
+		diags.AddAttributeError(
+			resourcePath.AtName("service"),
+			"Integration not installed",
+			fmt.Sprintf("%q is not an integration installed in this P0 organization. Installed integrations: %s.", service, quotedList(keys)),
+		)
+		return diags
+	}
+	integration := installed[index]
+
+	if resource.AccessType.IsUnknown() || integration.AccessTypes == nil {
+		return diags
+	}
+	accessTypes := integration.AccessTypes
+	if accessType := resource.AccessType.ValueString(); !resource.AccessType.IsNull() && accessType != "any" {
+		index := slices.IndexFunc(accessTypes, func(a AccessTypeJson) bool { return a.Key == accessType })
+		if index < 0 {
+			keys := make([]string, 0, len(accessTypes))
+			for _, a := range accessTypes {
+				keys = append(keys, a.Key)
+			}
+			diags.AddAttributeError(
+				resourcePath.AtName("access_type"),
+				"Unsupported access type",
+				fmt.Sprintf("The %q integration does not support access type %q. Supported access types: 'any', %s.", service, accessType, quotedList(keys)),
+			)
+			return diags
+		}
+		accessTypes = accessTypes[index : index+1]
+	}
+
+	if resource.Filters.IsNull() || resource.Filters.IsUnknown() {
+		return diags
+	}
+	var supported []string
+	for _, a := range accessTypes {
+		if a.Filters == nil {
+			return diags
+		}
+		for _, filter := range a.Filters {
+			if !slices.Contains(supported, filter) {
+				supported = append(supported, filter)
+			}
+		}
+	}
+	filterKeys := make([]string, 0, len(resource.Filters.Elements()))
+	for key := range resource.Filters.Elements() {
+		filterKeys = append(filterKeys, key)
+	}
+	slices.Sort(filterKeys)
+	for _, key := range filterKeys {
+		if !slices.Contains(supported, key) {
+			diags.AddAttributeError(
+				resourcePath.AtName("filters").AtMapKey(key),
+				"Unsupported resource filter",
+				fmt.Sprintf("%q is not a supported filter for the %q integration. Supported filters: %s.", key, service, quotedList(supported)),
+			)
+		}
+	}
+	return diags
+}
+
+// quotedList renders values as a sorted, quoted, comma-separated list.
+func quotedList(values []string) string {
+	if len(values) == 0 {
+		return "(none)"
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	quoted := make([]string, len(sorted))
+	for i, value := range sorted {
+		quoted[i] = fmt.Sprintf("'%s'", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// getInstalledIntegrations lists the organization's installed integrations.
+// Failing to list them only warns, since P0 still validates policies at apply
+// time; ok is false if the list is unavailable, or not of the expected shape
+// (see InstalledIntegrationsJson).
+func getInstalledIntegrations(ctx context.Context, data *internal.P0ProviderData, diags *diag.Diagnostics) (installed []InstalledIntegrationJson, ok bool) {
+	var json InstalledIntegrationsJson
+	httpResponse, httpErr := data.Get("integrations", &json)
+	if httpErr != nil {
+		// Older P0 deployments don't list integrations; fall back to validating at apply.
+		if httpResponse != nil && httpResponse.StatusCode == 404 {
+			tflog.Debug(ctx, "Integration list not available (404), skipping access policy integration validation")
+			return nil, false
+		}
+		diags.AddWarning(
+			"Unable to validate access policy integrations",
+			fmt.Sprintf("Unable to list installed integrations; `resource` and notifications will be validated by P0 at apply time:\n%s", httpErr),
+		)
+		return nil, false
+	}
+	if json.Integrations == nil {
+		tflog.Debug(ctx, "Integration list has no 'integrations', skipping access policy integration validation")
+		return nil, false
+	}
+	return json.Integrations, true
+}
+
+// policyIntegrationsPlan is the parts of a planned policy that are checked
+// against the organization's installed integrations.
+type policyIntegrationsPlan struct {
+	path                    path.Path
+	resource                *resourcePlanModel
+	notifications           []notificationPlanModel
+	breakGlassNotifications []notificationPlanModel
+}
+
+// newPolicyIntegrationsPlan decodes the planned attributes of the policy at
+// policyPath. Null and unknown values are skipped.
+func newPolicyIntegrationsPlan(ctx context.Context, attributes map[string]attr.Value, policyPath path.Path) (policyIntegrationsPlan, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	plan := policyIntegrationsPlan{path: policyPath}
+	if resourceObject, ok := attributes["resource"].(types.Object); ok && !resourceObject.IsNull() && !resourceObject.IsUnknown() {
+		var model resourcePlanModel
+		diags.Append(resourceObject.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+		if model.Type.ValueString() == "integration" {
+			plan.resource = &model
+		}
+	}
+	notifications, notificationDiags := notificationsFromPlan(ctx, attributes["notifications"])
+	diags.Append(notificationDiags...)
+	plan.notifications = notifications
+	if breakGlass, ok := attributes["break_glass"].(types.Object); ok && !breakGlass.IsNull() && !breakGlass.IsUnknown() {
+		notifications, notificationDiags := notificationsFromPlan(ctx, breakGlass.Attributes()["notifications"])
+		diags.Append(notificationDiags...)
+		plan.breakGlassNotifications = notifications
+	}
+	return plan, diags
+}
+
+// needsIntegrations reports whether the plan has anything to check.
+func (plan policyIntegrationsPlan) needsIntegrations() bool {
+	return plan.resource != nil || requiresIntegrations(plan.notifications) || requiresIntegrations(plan.breakGlassNotifications)
+}
+
+func (plan policyIntegrationsPlan) validate(installed []InstalledIntegrationJson) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.resource != nil {
+		diags.Append(validateResourceIntegration(*plan.resource, installed, plan.path.AtName("resource"))...)
+	}
+	diags.Append(validateNotificationIntegrations(plan.notifications, installed, plan.path.AtName("notifications"))...)
+	diags.Append(validateNotificationIntegrations(plan.breakGlassNotifications, installed, plan.path.AtName("break_glass").AtName("notifications"))...)
+	return diags
+}
+
+// validateIntegrations validates the policy's `resource` and notifications
+// against the organization's installed integrations.
+func (policy *AccessPolicy) validateIntegrations(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	attributes := map[string]attr.Value{}
+	for _, name := range []string{"resource", "notifications", "break_glass"} {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &value)...)
+		attributes[name] = value
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The policy is at the root of its own schema.
+	plan, diags := newPolicyIntegrationsPlan(ctx, attributes, path.Empty())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !plan.needsIntegrations() {
+		return
+	}
+
+	installed, ok := getInstalledIntegrations(ctx, policy.data, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(plan.validate(installed)...)
+}
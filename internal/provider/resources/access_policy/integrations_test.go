@@ -0,0 +1,162 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+func TestValidateResourceIntegration(t *testing.T) {
+	installed := []InstalledIntegrationJson{
+		{Key: "aws", AccessTypes: []AccessTypeJson{
+			{Key: "role", Filters: []string{"account", "name"}},
+			{Key: "permission-set", Filters: []string{"account", "permission-set"}},
+		}},
+		{Key: "okta", AccessTypes: []AccessTypeJson{{Key: "group", Filters: []string{"group"}}}},
+	}
+	filters := func(keys ...string) types.Map {
+		elements := map[string]attr.Value{}
+		for _, key := range keys {
+			elements[key] = types.StringValue("")
+		}
+		return types.MapValueMust(types.StringType, elements)
+	}
+	integration := func(service types.String, accessType types.String, filters types.Map) resourcePlanModel {
+		return resourcePlanModel{Type: types.StringValue("integration"), Service: service, AccessType: accessType, Filters: filters}
+	}
+	noFilters := types.MapNull(types.StringType)
+
+	cases := []struct {
+		name     string
+		resource resourcePlanModel
+		wantPath string
+		wantErr  string
+	}{
+		{name: "any resource", resource: resourcePlanModel{Type: types.StringValue("any")}},
+		{name: "installed service", resource: integration(types.StringValue("aws"), types.StringNull(), noFilters)},
+		{name: "unknown service", resource: integration(types.StringUnknown(), types.StringValue("bogus"), noFilters)},
+		{
+			name:     "service not installed",
+			resource: integration(types.StringValue("gcloud"), types.StringNull(), noFilters),
+			wantPath: "resource.service",
+			wantErr:  "Installed integrations: 'aws', 'okta'",
+		},
+		{
+			name:     "unsupported access type",
+			resource: integration(types.StringValue("aws"), types.StringValue("group"), noFilters),
+			wantPath: "resource.access_type",
+			wantErr:  "Supported access types: 'any', 'permission-set', 'role'",
+		},
+		{name: "filters of the access type", resource: integration(types.StringValue("aws"), types.StringValue("role"), filters("account", "name"))},
+		{name: "filters of any access type", resource: integration(types.StringValue("aws"), types.StringValue("any"), filters("name", "permission-set"))},
+		{
+			name:     "filter of another access type",
+			resource: integration(types.StringValue("aws"), types.StringValue("role"), filters("account", "permission-set")),
+			wantPath: `resource.filters["permission-set"]`,
+			wantErr:  "Supported filters: 'account', 'name'",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := validateResourceIntegration(c.resource, installed, path.Root("resource"))
+			if c.wantErr == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("got %d errors; want 1: %v", diags.ErrorsCount(), diags)
+			}
+			d := diags.Errors()[0]
+			if !strings.Contains(d.Detail(), c.wantErr) {
+				t.Errorf("detail = %q; want it to contain %q", d.Detail(), c.wantErr)
+			}
+			withPath, ok := d.(interface{ Path() path.Path })
+			if !ok || withPath.Path().String() != c.wantPath {
+				t.Errorf("diagnostic is not on %s: %v", c.wantPath, d)
+			}
+		})
+	}
+}
+
+// TestGetInstalledIntegrations verifies that `GET integrations` is decoded, and
+// that an unavailable or unexpected response skips validation rather than
+// rejecting policies.
+func TestGetInstalledIntegrations(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      int
+		body        string
+		want        []InstalledIntegrationJson
+		wantOk      bool
+		wantWarning bool
+	}{
+		{
+			name:   "integrations",
+			status: http.StatusOK,
+			body:   `{"integrations": [{"key": "aws", "accessTypes": [{"key": "role", "filters": ["account"]}]}, {"key": "slack"}]}`,
+			want: []InstalledIntegrationJson{
+				{Key: "aws", AccessTypes: []AccessTypeJson{{Key: "role", Filters: []string{"account"}}}},
+				{Key: "slack"},
+			},
+			wantOk: true,
+		},
+		{name: "no integrations", status: http.StatusOK, body: `{"integrations": []}`, want: []InstalledIntegrationJson{}, wantOk: true},
+		{name: "unexpected shape", status: http.StatusOK, body: `{"items": []}`},
+		{name: "not found", status: http.StatusNotFound, body: `{"error": "not found"}`},
+		{name: "error", status: http.StatusInternalServerError, body: `{"error": "internal"}`, wantWarning: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/integrations" {
+					t.Errorf("request = %s %s; want GET /integrations", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(c.status)
+				_, _ = w.Write([]byte(c.body))
+			}))
+			t.Cleanup(server.Close)
+			data := &internal.P0ProviderData{BaseUrl: server.URL, Authentication: "Bearer x", Client: server.Client()}
+
+			var diags diag.Diagnostics
+			installed, ok := getInstalledIntegrations(context.Background(), data, &diags)
+			if ok != c.wantOk || !reflect.DeepEqual(installed, c.want) {
+				t.Errorf("getInstalledIntegrations() = %+v, %v; want %+v, %v", installed, ok, c.want, c.wantOk)
+			}
+			if diags.HasError() || (len(diags.Warnings()) > 0) != c.wantWarning {
+				t.Errorf("diagnostics = %v; want a warning: %v", diags, c.wantWarning)
+			}
+		})
+	}
+}
+
+// TestValidateResourceIntegrationUnlisted verifies that access types and
+// filters are only checked if P0 lists them.
+func TestValidateResourceIntegrationUnlisted(t *testing.T) {
+	installed := []InstalledIntegrationJson{
+		{Key: "slack"},
+		{Key: "aws", AccessTypes: []AccessTypeJson{{Key: "role"}}},
+	}
+	filters := types.MapValueMust(types.StringType, map[string]attr.Value{"account": types.StringValue("")})
+	for _, resource := range []resourcePlanModel{
+		{Type: types.StringValue("integration"), Service: types.StringValue("slack"), AccessType: types.StringValue("channel"), Filters: filters},
+		{Type: types.StringValue("integration"), Service: types.StringValue("aws"), AccessType: types.StringValue("role"), Filters: filters},
+	} {
+		if diags := validateResourceIntegration(resource, installed, path.Root("resource")); diags.HasError() {
+			t.Errorf("%s: unexpected diagnostics: %v", resource.Service.ValueString(), diags)
+		}
+	}
+}
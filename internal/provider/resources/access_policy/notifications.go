@@ -0,0 +1,114 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NotificationModel is a destination that P0 notifies about access requests.
+type NotificationModel struct {
+	Integration string `json:"integration" tfsdk:"integration"`
+	Target      string `json:"target" tfsdk:"target"`
+}
+
+// notificationIntegrations are the integrations P0 can notify. Except for
+// 'email', each must be installed, under the same key.
+var notificationIntegrations = []string{"email", "ms-teams", "pagerduty", "slack"}
+
+func notificationsAttribute(markdownDescription string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: markdownDescription,
+		Optional:            true,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"integration": schema.StringAttribute{
+					MarkdownDescription: `How P0 notifies. May be one of:
+    - 'email': Sends an email to 'target', an email address
+    - 'ms-teams': Posts to 'target', a Microsoft Teams channel ID
+    - 'pagerduty': Triggers an incident on 'target', a PagerDuty service ID
+    - 'slack': Posts to 'target', a Slack channel ID`,
+					Required: true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(notificationIntegrations...),
+					},
+				},
+				"target": schema.StringAttribute{
+					MarkdownDescription: "Where P0 sends notifications; see 'integration'.",
+					Required:            true,
+				},
+			},
+		},
+	}
+}
+
+// policyNotificationsAttribute builds a policy's `notifications` schema, which
+// only exists at schema version 10 and later.
+func policyNotificationsAttribute() schema.ListNestedAttribute {
+	return notificationsAttribute(`Where P0 sends this policy's approval requests, in addition to the organization-wide
+channels. Each integration other than 'email' must be installed in the P0 organization; this is checked when planning.`)
+}
+
+// notificationPlanModel is a notification with every value possibly unknown,
+// as it is during planning.
+type notificationPlanModel struct {
+	Integration types.String `tfsdk:"integration"`
+	Target      types.String `tfsdk:"target"`
+}
+
+// notificationsFromPlan decodes a planned `notifications` list, skipping it
+// if it is null or unknown.
+func notificationsFromPlan(ctx context.Context, value attr.Value) ([]notificationPlanModel, diag.Diagnostics) {
+	list, ok := value.(types.List)
+	if !ok || list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+	var notifications []notificationPlanModel
+	diags := list.ElementsAs(ctx, &notifications, false)
+	return notifications, diags
+}
+
+// requiresIntegrations reports whether any notification must be checked
+// against the installed integrations.
+func requiresIntegrations(notifications []notificationPlanModel) bool {
+	return slices.ContainsFunc(notifications, func(n notificationPlanModel) bool {
+		return !n.Integration.IsUnknown() && n.Integration.ValueString() != "email"
+	})
+}
+
+// validateNotificationIntegrations checks that the integration of each
+// notification, other than 'email', is installed. Unknown values are skipped.
+func validateNotificationIntegrations(notifications []notificationPlanModel, installed []InstalledIntegrationJson, notificationsPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	keys := make([]string, 0, len(installed))
+	for _, integration := range installed {
+		keys = append(keys, integration.Key)
+	}
+	for i, notification := range notifications {
+		integration := notification.Integration.ValueString()
+		if notification.Integration.IsUnknown() || integration == "email" || slices.Contains(keys, integration) {
+			continue
+		}
+		diags.AddAttributeError(
+			notificationsPath.AtListIndex(i).AtName("integration"),
+			"Integration not installed",
+			fmt.Sprintf("P0 can't notify through %q, because it is not an integration installed in this P0 organization. Installed integrations: %s.", integration, quotedList(keys)),
+		)
+	}
+	return diags
+}
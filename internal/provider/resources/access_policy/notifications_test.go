@@ -0,0 +1,67 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateNotificationIntegrations(t *testing.T) {
+	installed := []InstalledIntegrationJson{{Key: "aws"}, {Key: "slack"}}
+	notification := func(integration types.String) notificationPlanModel {
+		return notificationPlanModel{Integration: integration, Target: types.StringValue("target")}
+	}
+
+	cases := []struct {
+		name          string
+		notifications []notificationPlanModel
+		wantRequired  bool
+		wantPath      string
+		wantErr       string
+	}{
+		{name: "no notifications"},
+		{name: "email", notifications: []notificationPlanModel{notification(types.StringValue("email"))}},
+		{name: "unknown integration", notifications: []notificationPlanModel{notification(types.StringUnknown())}},
+		{name: "installed", notifications: []notificationPlanModel{notification(types.StringValue("slack"))}, wantRequired: true},
+		{
+			name: "not installed",
+			notifications: []notificationPlanModel{
+				notification(types.StringValue("slack")),
+				notification(types.StringValue("pagerduty")),
+			},
+			wantRequired: true,
+			wantPath:     "notifications[1].integration",
+			wantErr:      "Installed integrations: 'aws', 'slack'",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := requiresIntegrations(c.notifications); got != c.wantRequired {
+				t.Errorf("requiresIntegrations = %v; want %v", got, c.wantRequired)
+			}
+			diags := validateNotificationIntegrations(c.notifications, installed, path.Root("notifications"))
+			if c.wantErr == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("got %d errors; want 1: %v", diags.ErrorsCount(), diags)
+			}
+			d := diags.Errors()[0]
+			if !strings.Contains(d.Detail(), c.wantErr) {
+				t.Errorf("detail = %q; want it to contain %q", d.Detail(), c.wantErr)
+			}
+			withPath, ok := d.(interface{ Path() path.Path })
+			if !ok || withPath.Path().String() != c.wantPath {
+				t.Errorf("diagnostic is not on %s: %v", c.wantPath, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,504 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/p0-security/terraform-provider-p0/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccessPolicyDocument{}
+var _ resource.ResourceWithImportState = &AccessPolicyDocument{}
+var _ resource.ResourceWithValidateConfig = &AccessPolicyDocument{}
+var _ resource.ResourceWithModifyPlan = &AccessPolicyDocument{}
+
+// AccessPolicyDocument manages an access policy written as a document in the
+// P0 API's wire format (AccessPolicyJson), e.g. a YAML file authored outside
+// of Terraform.
+type AccessPolicyDocument struct {
+	data *internal.P0ProviderData
+}
+
+type AccessPolicyDocumentModel struct {
+	Id      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Content types.String `tfsdk:"content"`
+}
+
+func NewAccessPolicyDocument() resource.Resource {
+	return &AccessPolicyDocument{}
+}
+
+// parsePolicyDocument decodes a JSON or YAML policy document. Unknown fields
+// are rejected, so that misspelled fields fail instead of being ignored.
+func parsePolicyDocument(content string) (AccessPolicyJson, error) {
+	var document AccessPolicyJson
+	// YAML is a superset of JSON, so both are decoded as YAML, then converted
+	// to JSON to apply the wire format's field names.
+	var decoded any
+	if err := yaml.Unmarshal([]byte(content), &decoded); err != nil {
+		return document, fmt.Errorf("the document is not valid JSON or YAML: %w", err)
+	}
+	if _, ok := decoded.(map[string]any); !ok {
+		return document, fmt.Errorf("the document must be an object")
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return document, fmt.Errorf("the document can't be converted to JSON: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&document); err != nil {
+		return document, fmt.Errorf("the document is not an access policy: %w", err)
+	}
+
+	var missing []string
+	if document.Name == nil || *document.Name == "" {
+		missing = append(missing, "name")
+	}
+	if document.Requestor.Type == "" {
+		missing = append(missing, "requestor.type")
+	}
+	if document.Resource.Type == "" {
+		missing = append(missing, "resource.type")
+	}
+	if document.Approval == nil {
+		missing = append(missing, "approval")
+	}
+	if len(missing) > 0 {
+		return document, fmt.Errorf("the document is missing %s", strings.Join(missing, ", "))
+	}
+	return document, nil
+}
+
+// formatPolicyDocument renders a policy as a JSON document.
+func formatPolicyDocument(model AccessPolicyModelV11) (string, error) {
+	encoded, err := json.MarshalIndent(toJson(model), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("unable to encode access policy %q: %w", *model.Name, err)
+	}
+	return string(encoded) + "\n", nil
+}
+
+// documentsEqual reports whether two policies have the same configurable
+// attributes, ignoring the order and labels of their groups and the order of
+// their approval rules (see semantic.go). An omitted `disabled` is false.
+func documentsEqual(a AccessPolicyModelV11, b AccessPolicyModelV11) (bool, error) {
+	if !isDisabled(a) && !isDisabled(b) {
+		a.Disabled, b.Disabled = nil, nil
+	}
+	if !requestorsEqual(a.Requestor, b.Requestor) || !approvalsEqual(a.Approval, b.Approval) {
+		return false, nil
+	}
+	// The other attributes are compared by their documents.
+	b.Requestor, b.Approval = a.Requestor, a.Approval
+	aDocument, err := formatPolicyDocument(a)
+	if err != nil {
+		return false, err
+	}
+	bDocument, err := formatPolicyDocument(b)
+	if err != nil {
+		return false, err
+	}
+	return aDocument == bDocument, nil
+}
+
+// validatePolicyDocument applies p0_access_policy's schema validators to a
+// parsed document.
+func validatePolicyDocument(ctx context.Context, document AccessPolicyJson) diag.Diagnostics {
+	policySchema := newAccessPolicySchema(currentSchemaVersion)
+	value, diags := types.ObjectValueFrom(ctx, policyObjectType.AttrTypes, toModel(document))
+	if diags.HasError() {
+		return diags
+	}
+	raw, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		diags.AddError("Invalid access policy document", err.Error())
+		return diags
+	}
+	config := tfsdk.Config{Schema: policySchema, Raw: raw}
+	for _, name := range sortedNames(policySchema.Attributes) {
+		validateAttribute(ctx, config, path.Root(name), policySchema.Attributes[name], value.Attributes()[name], &diags)
+	}
+	return diags
+}
+
+// validateAttribute runs an attribute's validators, and those of its nested
+// attributes, against value, as Terraform would against configuration.
+func validateAttribute(ctx context.Context, config tfsdk.Config, p path.Path, attribute schema.Attribute, value attr.Value, diags *diag.Diagnostics) {
+	if attribute.IsRequired() && value.IsNull() {
+		diags.AddAttributeError(p, "Missing required attribute", fmt.Sprintf("The attribute %q is required.", p))
+		return
+	}
+	if attribute.IsComputed() && !attribute.IsOptional() {
+		return
+	}
+
+	switch a := attribute.(type) {
+	case schema.StringAttribute:
+		for _, v := range a.Validators {
+			resp := &validator.StringResponse{}
+			v.ValidateString(ctx, validator.StringRequest{Path: p, PathExpression: p.Expression(), Config: config, ConfigValue: value.(types.String)}, resp)
+			diags.Append(resp.Diagnostics...)
+		}
+	case schema.BoolAttribute:
+		for _, v := range a.Validators {
+			resp := &validator.BoolResponse{}
+			v.ValidateBool(ctx, validator.BoolRequest{Path: p, PathExpression: p.Expression(), Config: config, ConfigValue: value.(types.Bool)}, resp)
+			diags.Append(resp.Diagnostics...)
+		}
+	case schema.Int64Attribute:
+		for _, v := range a.Validators {
+			resp := &validator.Int64Response{}
+			v.ValidateInt64(ctx, validator.Int64Request{Path: p, PathExpression: p.Expression(), Config: config, ConfigValue: value.(types.Int64)}, resp)
+			diags.Append(resp.Diagnostics...)
+		}
+	case schema.ListAttribute:
+		validateListValue(ctx, config, p, a.Validators, listValue(ctx, value, diags), diags)
+	case schema.SingleNestedAttribute:
+		object := value.(types.Object)
+		validateNestedObject(ctx, config, p, a.Validators, a.Attributes, object, diags)
+	case schema.ListNestedAttribute:
+		list := listValue(ctx, value, diags)
+		validateListValue(ctx, config, p, a.Validators, list, diags)
+		for i, element := range list.Elements() {
+			validateNestedObject(ctx, config, p.AtListIndex(i), a.NestedObject.Validators, a.NestedObject.Attributes, element.(types.Object), diags)
+		}
+	case schema.MapAttribute:
+		validateMapValue(ctx, config, p, a.Validators, mapValue(ctx, value, diags), diags)
+	case schema.MapNestedAttribute:
+		m := mapValue(ctx, value, diags)
+		validateMapValue(ctx, config, p, a.Validators, m, diags)
+		elements := m.Elements()
+		for _, key := range sortedNames(elements) {
+			validateNestedObject(ctx, config, p.AtMapKey(key), a.NestedObject.Validators, a.NestedObject.Attributes, elements[key].(types.Object), diags)
+		}
+	}
+}
+
+// listValue returns value, a list of a possibly custom type (see semantic.go),
+// as a plain list.
+func listValue(ctx context.Context, value attr.Value, diags *diag.Diagnostics) types.List {
+	list, listDiags := value.(basetypes.ListValuable).ToListValue(ctx)
+	diags.Append(listDiags...)
+	return list
+}
+
+// mapValue returns value, a map of a possibly custom type (see semantic.go),
+// as a plain map.
+func mapValue(ctx context.Context, value attr.Value, diags *diag.Diagnostics) types.Map {
+	m, mapDiags := value.(basetypes.MapValuable).ToMapValue(ctx)
+	diags.Append(mapDiags...)
+	return m
+}
+
+func validateMapValue(ctx context.Context, config tfsdk.Config, p path.Path, validators []validator.Map, value types.Map, diags *diag.Diagnostics) {
+	for _, v := range validators {
+		resp := &validator.MapResponse{}
+		v.ValidateMap(ctx, validator.MapRequest{Path: p, PathExpression: p.Expression(), Config: config, ConfigValue: value}, resp)
+		diags.Append(resp.Diagnostics...)
+	}
+}
+
+func validateListValue(ctx context.Context, config tfsdk.Config, p path.Path, validators []validator.List, value types.List, diags *diag.Diagnostics) {
+	for _, v := range validators {
+		resp := &validator.ListResponse{}
+		v.ValidateList(ctx, validator.ListRequest{Path: p, PathExpression: p.Expression(), Config: config, ConfigValue: value}, resp)
+		diags.Append(resp.Diagnostics...)
+	}
+}
+
+func validateNestedObject(ctx context.Context, config tfsdk.Config, p path.Path, validators []validator.Object, attributes map[string]schema.Attribute, value types.Object, diags *diag.Diagnostics) {
+	for _, v := range validators {
+		resp := &validator.ObjectResponse{}
+		v.ValidateObject(ctx, validator.ObjectRequest{Path: p, PathExpression: p.Expression(), Config: config, ConfigValue: value}, resp)
+		diags.Append(resp.Diagnostics...)
+	}
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	for _, name := range sortedNames(attributes) {
+		validateAttribute(ctx, config, p.AtName(name), attributes[name], value.Attributes()[name], diags)
+	}
+}
+
+// addDocumentDiagnostics reports a document's diagnostics against `content`,
+// naming the offending field of the document.
+func addDocumentDiagnostics(diags *diag.Diagnostics, documentDiags diag.Diagnostics) {
+	for _, d := range documentDiags {
+		detail := d.Detail()
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			detail = fmt.Sprintf("%s: %s", withPath.Path(), detail)
+		}
+		if d.Severity() == diag.SeverityError {
+			diags.AddAttributeError(path.Root("content"), d.Summary(), detail)
+		} else {
+			diags.AddAttributeWarning(path.Root("content"), d.Summary(), detail)
+		}
+	}
+}
+
+func (d *AccessPolicyDocument) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_policy_document"
+}
+
+func (d *AccessPolicyDocument) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `An access policy written as a JSON or YAML document, in the same format as the P0 API, e.g. a policy reviewed
+in a separate repository. The document is checked with the same validators as ` + "`p0_access_policy`" + `.
+
+Fields use the API's names, in camel case (e.g. ` + "`accessType`" + `, ` + "`breakGlass`" + `), and groups are objects with
+` + "`directory`" + `, ` + "`id`" + `, and ` + "`label`" + `. Importing a policy stores its document as JSON in ` + "`content`" + `,
+which is a starting point for a policy file.`,
+		Attributes: map[string]schema.Attribute{
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The policy document, e.g. `file(\"policies/engineering.yaml\")`. Changing the document's `name` renames the policy in place.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The policy's identifier in P0. Unlike `name`, this does not change when the policy is renamed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the policy, from the document.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AccessPolicyDocument) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	data := internal.Configure(&req, resp)
+	if data != nil {
+		d.data = data
+	}
+}
+
+// ValidateConfig validates the document, if it is known, so that
+// `terraform validate` reports invalid documents.
+func (d *AccessPolicyDocument) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var content types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	if resp.Diagnostics.HasError() || content.IsNull() || content.IsUnknown() {
+		return
+	}
+	document, err := parsePolicyDocument(content.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid access policy document", err.Error()+".")
+		return
+	}
+	addDocumentDiagnostics(&resp.Diagnostics, validatePolicyDocument(ctx, document))
+}
+
+// ModifyPlan plans the policy's name from its document.
+func (d *AccessPolicyDocument) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan AccessPolicyDocumentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Content.IsUnknown() {
+		return
+	}
+	document, err := parsePolicyDocument(plan.Content.ValueString())
+	if err != nil {
+		// Reported by ValidateConfig.
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), document.Name)...)
+}
+
+// planDocument parses the planned document for Create and Update.
+func planDocument(ctx context.Context, plan tfsdk.Plan, diags *diag.Diagnostics) (AccessPolicyDocumentModel, AccessPolicyModelV11, bool) {
+	var model AccessPolicyDocumentModel
+	diags.Append(plan.Get(ctx, &model)...)
+	if diags.HasError() {
+		return model, AccessPolicyModelV11{}, false
+	}
+	document, err := parsePolicyDocument(model.Content.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("content"), "Invalid access policy document", err.Error()+".")
+		return model, AccessPolicyModelV11{}, false
+	}
+	return model, toModel(document), true
+}
+
+// stateFromPolicy returns the state for a policy read from P0. The document
+// is kept unless the policy differs from it, in which case the policy's own
+// document replaces it, so that the difference plans as a change to
+// `content`.
+func stateFromPolicy(model AccessPolicyDocumentModel, document *AccessPolicyModelV11, json AccessPolicyJson) (AccessPolicyDocumentModel, error) {
+	current := toModel(json)
+	changed := document == nil
+	if document != nil {
+		equal, err := documentsEqual(*document, current)
+		if err != nil {
+			return model, err
+		}
+		changed = !equal
+	}
+	if changed {
+		content, err := formatPolicyDocument(current)
+		if err != nil {
+			return model, err
+		}
+		model.Content = types.StringValue(content)
+	}
+	model.Id = current.Id
+	model.Name = types.StringPointerValue(current.Name)
+	return model, nil
+}
+
+func (d *AccessPolicyDocument) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var diag = &resp.Diagnostics
+	model, document, ok := planDocument(ctx, req.Plan, diag)
+	if !ok {
+		return
+	}
+
+	json := toJson(document)
+	var updatedJson AccessPolicyJson
+	_, postErr := d.data.Post(getPath(*document.Name), &json, &updatedJson)
+	if postErr != nil {
+		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to create access policy:\n%s", postErr))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created access policy from document: %+v", updatedJson))
+
+	state, err := stateFromPolicy(model, &document, updatedJson)
+	if err != nil {
+		diag.AddError("Error reading access policy", err.Error())
+		return
+	}
+	diag.Append(resp.State.Set(ctx, state)...)
+}
+
+func (d *AccessPolicyDocument) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var diag = &resp.Diagnostics
+	var model AccessPolicyDocumentModel
+	diag.Append(req.State.Get(ctx, &model)...)
+	if diag.HasError() {
+		return
+	}
+
+	var json AccessPolicyJson
+	httpResponse, httpErr := d.data.Get(getPath(model.Name.ValueString()), &json)
+	if httpErr != nil {
+		if httpResponse != nil && httpResponse.StatusCode == 404 {
+			tflog.Debug(ctx, "Access policy not found (404), removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to read access policy:\n%s", httpErr))
+		return
+	}
+
+	// An imported policy has no document yet.
+	var document *AccessPolicyModelV11
+	if parsed, err := parsePolicyDocument(model.Content.ValueString()); err == nil {
+		prior := toModel(parsed)
+		document = &prior
+	}
+	state, err := stateFromPolicy(model, document, json)
+	if err != nil {
+		diag.AddError("Error reading access policy", err.Error())
+		return
+	}
+	diag.Append(resp.State.Set(ctx, state)...)
+}
+
+func (d *AccessPolicyDocument) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var diag = &resp.Diagnostics
+	model, document, ok := planDocument(ctx, req.Plan, diag)
+	if !ok {
+		return
+	}
+	var current AccessPolicyDocumentModel
+	diag.Append(req.State.Get(ctx, &current)...)
+	if diag.HasError() {
+		return
+	}
+
+	// Rename the policy in place first, so that it's never absent
+	if priorName := current.Name.ValueString(); priorName != *document.Name {
+		var renamedJson AccessPolicyJson
+		_, renameErr := d.data.Post(getRenamePath(priorName), &RenameJson{Name: *document.Name}, &renamedJson)
+		if renameErr != nil {
+			diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to rename access policy:\n%s", renameErr))
+			return
+		}
+	}
+
+	json := toJson(document)
+	var updatedJson AccessPolicyJson
+	_, putErr := d.data.Put(getPath(*document.Name), &json, &updatedJson)
+	if putErr != nil {
+		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to update access policy:\n%s", putErr))
+		// The policy may already have been renamed; record its new name, so the
+		// next plan doesn't try to rename it from a name that no longer exists.
+		diag.Append(resp.State.SetAttribute(ctx, path.Root("name"), document.Name)...)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updated access policy from document: %+v", updatedJson))
+
+	state, err := stateFromPolicy(model, &document, updatedJson)
+	if err != nil {
+		diag.AddError("Error reading access policy", err.Error())
+		return
+	}
+	diag.Append(resp.State.Set(ctx, state)...)
+}
+
+func (d *AccessPolicyDocument) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model AccessPolicyDocumentModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, deleteErr := d.data.Delete(getPath(model.Name.ValueString()))
+	if deleteErr != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to delete access policy:\n%s", deleteErr))
+	}
+}
+
+// ImportState imports a policy by name or id; Read then stores its document.
+func (d *AccessPolicyDocument) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	existing, _, err := listPolicies(d.data)
+	if err != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to list access policies:\n%s", err))
+		return
+	}
+	name, err := resolvePolicyName(req.ID, existing)
+	if err != nil {
+		resp.Diagnostics.AddError("Access policy not found", fmt.Sprintf("Unable to import access policy: %s.", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const engineeringYaml = `
+name: engineering
+requestor:
+  type: group
+  effect: keep
+  groups:
+    - directory: okta
+      id: "00g1"
+      label: Engineering
+resource:
+  type: integration
+  service: aws
+  accessType: role
+approval:
+  - type: p0
+    options:
+      requireReason: true
+`
+
+func TestParsePolicyDocument(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		document, err := parsePolicyDocument(engineeringYaml)
+		if err != nil {
+			t.Fatalf("parsePolicyDocument: %v", err)
+		}
+		if *document.Name != "engineering" || document.Requestor.Groups[0].Label == nil || *document.Resource.AccessType != "role" ||
+			!*document.Approval[0].Options.RequireReason {
+			t.Errorf("parsePolicyDocument = %+v", document)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		document, err := parsePolicyDocument(`{"name": "any", "requestor": {"type": "any"}, "resource": {"type": "any"}, "approval": []}`)
+		if err != nil {
+			t.Fatalf("parsePolicyDocument: %v", err)
+		}
+		if *document.Name != "any" || document.Approval == nil {
+			t.Errorf("parsePolicyDocument = %+v", document)
+		}
+	})
+
+	errors := map[string]string{
+		"unknown field":  strings.Replace(engineeringYaml, "accessType", "access_type", 1),
+		"missing fields": "name: engineering\nrequestor: {}\n",
+		"not an object":  "- name: engineering\n",
+		"not yaml":       "name: [engineering",
+	}
+	for name, content := range errors {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parsePolicyDocument(content); err == nil {
+				t.Error("parsePolicyDocument succeeded; want an error")
+			}
+		})
+	}
+}
+
+func errorPaths(diags diag.Diagnostics) []string {
+	var paths []string
+	for _, d := range diags.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path().String())
+		}
+	}
+	return paths
+}
+
+func TestValidatePolicyDocument(t *testing.T) {
+	cases := []struct {
+		name      string
+		content   string
+		wantPaths []string
+	}{
+		{name: "valid", content: engineeringYaml},
+		{
+			name:      "type requirement",
+			content:   "{name: u, requestor: {type: user}, resource: {type: any}, approval: [{type: p0}]}",
+			wantPaths: []string{"requestor.uid"},
+		},
+		{
+			name:      "nonconsecutive stages",
+			content:   "{name: s, requestor: {type: any}, resource: {type: any}, approval: [{type: p0, stage: 2}]}",
+			wantPaths: []string{"approval"},
+		},
+		{
+			name:      "break glass without approver",
+			content:   "{name: b, requestor: {type: any}, resource: {type: any}, approval: [{type: p0}], breakGlass: {requireReview: true}}",
+			wantPaths: []string{"break_glass"},
+		},
+		{
+			name:      "empty schedule",
+			content:   "{name: e, requestor: {type: any}, resource: {type: any}, approval: [], schedule: {timezone: UTC}}",
+			wantPaths: []string{"schedule.days"},
+		},
+		{
+			name:      "invalid timezone",
+			content:   "{name: t, requestor: {type: any}, resource: {type: any}, approval: [], schedule: {timezone: Mars/Olympus, days: [monday]}}",
+			wantPaths: []string{"schedule.timezone"},
+		},
+		{
+			name:    "profile requestor",
+			content: "{name: p, requestor: {type: profile, effect: keep, profile: {directory: okta, attributes: {department: [Engineering]}}}, resource: {type: any}, approval: [{type: p0}]}",
+		},
+		{
+			name:      "profile requestor without values",
+			content:   "{name: p, requestor: {type: profile, effect: keep, profile: {directory: okta, attributes: {department: []}}}, resource: {type: any}, approval: [{type: p0}]}",
+			wantPaths: []string{`requestor.profile.attributes["department"]`},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			document, err := parsePolicyDocument(c.content)
+			if err != nil {
+				t.Fatalf("parsePolicyDocument: %v", err)
+			}
+			got := errorPaths(validatePolicyDocument(context.Background(), document))
+			if strings.Join(got, ",") != strings.Join(c.wantPaths, ",") {
+				t.Errorf("errors at %v; want %v", got, c.wantPaths)
+			}
+		})
+	}
+}
+
+func TestStateFromPolicy(t *testing.T) {
+	parsed, err := parsePolicyDocument(engineeringYaml)
+	if err != nil {
+		t.Fatalf("parsePolicyDocument: %v", err)
+	}
+	document := toModel(parsed)
+	prior := AccessPolicyDocumentModel{Content: types.StringValue(engineeringYaml)}
+
+	t.Run("unchanged", func(t *testing.T) {
+		json := parsed
+		json.Id = strPtr("pol_1")
+		json.Disabled = ptr(false)
+		json.Requestor.Groups = []GroupModelV1{group("00g1", "Engineering (Okta)")}
+		got, err := stateFromPolicy(prior, &document, json)
+		if err != nil || got.Content.ValueString() != engineeringYaml || got.Id.ValueString() != "pol_1" || got.Name.ValueString() != "engineering" {
+			t.Errorf("stateFromPolicy = %+v; want the prior document", got)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		json := parsed
+		json.Disabled = ptr(true)
+		got, err := stateFromPolicy(prior, &document, json)
+		if err != nil {
+			t.Fatalf("stateFromPolicy: %v", err)
+		}
+		if !strings.Contains(got.Content.ValueString(), `"disabled": true`) {
+			t.Errorf("Content = %s; want the policy's document", got.Content.ValueString())
+		}
+		reparsed, err := parsePolicyDocument(got.Content.ValueString())
+		if err != nil {
+			t.Fatalf("Content does not parse: %v", err)
+		}
+		if equal, err := documentsEqual(toModel(reparsed), toModel(json)); err != nil || !equal {
+			t.Errorf("Content does not round-trip: %v", err)
+		}
+	})
+
+	t.Run("imported", func(t *testing.T) {
+		got, err := stateFromPolicy(AccessPolicyDocumentModel{Name: types.StringValue("engineering")}, nil, parsed)
+		if err != nil || got.Content.IsNull() || !strings.Contains(got.Content.ValueString(), `"name": "engineering"`) {
+			t.Errorf("Content = %v; want the policy's document", got.Content)
+		}
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PrincipalSet{}
+var _ resource.ResourceWithImportState = &PrincipalSet{}
+
+// PrincipalSet is a named collection of directory groups and users, which
+// access policies reference by name in their `requestor` and `approval`
+// rules.
+type PrincipalSet struct {
+	data *internal.P0ProviderData
+}
+
+// PrincipalSetModel is both the Terraform and the JSON representation of a
+// principal set; it has no computed attributes.
+type PrincipalSetModel struct {
+	Name        string         `json:"name" tfsdk:"name"`
+	Description *string        `json:"description,omitempty" tfsdk:"description"`
+	Groups      []GroupModelV1 `json:"groups,omitempty" tfsdk:"groups"`
+	Users       []string       `json:"users,omitempty" tfsdk:"users"`
+}
+
+func NewPrincipalSet() resource.Resource {
+	return &PrincipalSet{}
+}
+
+func getPrincipalSetPath(name string) string {
+	return fmt.Sprintf("principal-set/name/%s", url.PathEscape(name))
+}
+
+// principalSetReferenceAttribute builds the `principal_set` attribute of
+// requestor and approval rules, which only exists at schema version 8 and
+// later.
+func principalSetReferenceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: `Required, and may only be used, if 'type' is 'principal-set'. The name of the principal set; reference a
+` + "`p0_principal_set`" + `'s ` + "`name`" + ` so that the set is created before, and destroyed after, the policy.`,
+		Optional: true,
+	}
+}
+
+// usersEqual reports whether two user lists contain the same email
+// addresses, ignoring order and case.
+func usersEqual(a []string, b []string) bool {
+	normalize := func(users []string) []string {
+		normalized := make([]string, len(users))
+		for i, user := range users {
+			normalized[i] = strings.ToLower(user)
+		}
+		slices.Sort(normalized)
+		return normalized
+	}
+	return slices.Equal(normalize(a), normalize(b))
+}
+
+func (s *PrincipalSet) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_principal_set"
+}
+
+func (s *PrincipalSet) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `A named collection of directory groups and users.
+
+Access policies match it with a 'principal-set' requestor, or allow its members to approve with a 'principal-set'
+approval rule, so that a group that many policies share can be changed in one place. A principal set can't be deleted
+while policies reference it.`,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the principal set. Changing the name replaces the set; use `create_before_destroy` so that the policies that reference it are updated before the prior set is deleted.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A human-readable description of the principal set.",
+				Optional:            true,
+			},
+			"groups": schema.ListNestedAttribute{
+				MarkdownDescription: "The directory groups in the set. Members of any of these groups are members of the set.",
+				Optional:            true,
+				NestedObject:        groupNestedObject(),
+				CustomType:          groupsType(groupNestedObject()),
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.AtLeastOneOf(path.MatchRoot("users")),
+				},
+			},
+			"users": schema.ListAttribute{
+				MarkdownDescription: "The email addresses of individual users in the set.",
+				ElementType:         types.StringType,
+				CustomType:          UsersType{ListType: basetypes.ListType{ElemType: types.StringType}},
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+		},
+	}
+}
+
+func (s *PrincipalSet) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	data := internal.Configure(&req, resp)
+	if data != nil {
+		s.data = data
+	}
+}
+
+func (s *PrincipalSet) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var diag = &resp.Diagnostics
+
+	var model PrincipalSetModel
+	diag.Append(req.Plan.Get(ctx, &model)...)
+	if diag.HasError() {
+		return
+	}
+
+	var updated PrincipalSetModel
+	_, postErr := s.data.Post(getPrincipalSetPath(model.Name), &model, &updated)
+	if postErr != nil {
+		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to create principal set:\n%s", postErr))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created principal set: %+v", updated))
+
+	diag.Append(resp.State.Set(ctx, updated)...)
+}
+
+func (s *PrincipalSet) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var diag = &resp.Diagnostics
+
+	var model PrincipalSetModel
+	diag.Append(req.State.Get(ctx, &model)...)
+	if diag.HasError() {
+		return
+	}
+
+	var json PrincipalSetModel
+	httpResponse, httpErr := s.data.Get(getPrincipalSetPath(model.Name), &json)
+	if httpErr != nil {
+		if httpResponse != nil && httpResponse.StatusCode == 404 {
+			tflog.Debug(ctx, "Principal set not found (404), removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to read principal set:\n%s", httpErr))
+		return
+	}
+
+	diag.Append(resp.State.Set(ctx, json)...)
+}
+
+func (s *PrincipalSet) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var diag = &resp.Diagnostics
+
+	var model PrincipalSetModel
+	diag.Append(req.Plan.Get(ctx, &model)...)
+	if diag.HasError() {
+		return
+	}
+
+	var updated PrincipalSetModel
+	_, putErr := s.data.Put(getPrincipalSetPath(model.Name), &model, &updated)
+	if putErr != nil {
+		diag.AddError("Error communicating with P0", fmt.Sprintf("Unable to update principal set:\n%s", putErr))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updated principal set: %+v", updated))
+
+	diag.Append(resp.State.Set(ctx, updated)...)
+}
+
+func (s *PrincipalSet) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model PrincipalSetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, deleteErr := s.data.Delete(getPrincipalSetPath(model.Name))
+	if deleteErr != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to delete principal set:\n%s", deleteErr))
+	}
+}
+
+// ImportState imports a principal set by name.
+func (s *PrincipalSet) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestPrincipalSetSemanticTypes verifies that a principal set's groups and
+// users are semantically equal regardless of order, group labels, and email
+// case.
+func TestPrincipalSetSemanticTypes(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&PrincipalSet{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	groupsType, ok := schemaResp.Schema.Attributes["groups"].GetType().(GroupsType)
+	if !ok {
+		t.Fatalf("groups type = %T; want GroupsType", schemaResp.Schema.Attributes["groups"].GetType())
+	}
+	groupsValue := func(groups ...GroupModelV1) GroupsValue {
+		list, diags := types.ListValueFrom(ctx, groupsType.ElemType, groups)
+		if diags.HasError() {
+			t.Fatalf("ListValueFrom: %v", diags)
+		}
+		return GroupsValue{ListValue: list}
+	}
+	priorGroups := groupsValue(group("1", "SRE"), group("2", "Ops"))
+	if equal, diags := groupsValue(group("2", "Operations"), group("1", "SRE")).ListSemanticEquals(ctx, priorGroups); diags.HasError() || !equal {
+		t.Errorf("groups semantically equal = %v, %v; want true", equal, diags)
+	}
+	if equal, diags := groupsValue(group("1", "SRE")).ListSemanticEquals(ctx, priorGroups); diags.HasError() || equal {
+		t.Errorf("changed groups semantically equal = %v, %v; want false", equal, diags)
+	}
+
+	if _, ok := schemaResp.Schema.Attributes["users"].GetType().(UsersType); !ok {
+		t.Fatalf("users type = %T; want UsersType", schemaResp.Schema.Attributes["users"].GetType())
+	}
+	usersValue := func(users ...string) UsersValue {
+		list, diags := types.ListValueFrom(ctx, types.StringType, users)
+		if diags.HasError() {
+			t.Fatalf("ListValueFrom: %v", diags)
+		}
+		return UsersValue{ListValue: list}
+	}
+	priorUsers := usersValue("Alice@example.com", "bob@example.com")
+	if equal, diags := usersValue("bob@example.com", "alice@example.com").ListSemanticEquals(ctx, priorUsers); diags.HasError() || !equal {
+		t.Errorf("reordered and recased users semantically equal = %v, %v; want true", equal, diags)
+	}
+	if equal, diags := usersValue("alice@example.com", "carol@example.com").ListSemanticEquals(ctx, priorUsers); diags.HasError() || equal {
+		t.Errorf("changed users semantically equal = %v, %v; want false", equal, diags)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	// Embeds the IANA time zone database, so that `timezone` validates the same
+	// way regardless of whether the host has zoneinfo files installed.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TimeRangeModel is a daily time window, in "HH:MM" 24-hour format. If End is
+// not after Start, the window spans midnight.
+type TimeRangeModel struct {
+	Start string `json:"start" tfsdk:"start"`
+	End   string `json:"end" tfsdk:"end"`
+}
+
+// ScheduleModel restricts when a policy applies.
+type ScheduleModel struct {
+	Timezone   string           `json:"timezone" tfsdk:"timezone"`
+	Days       []string         `json:"days,omitempty" tfsdk:"days"`
+	TimeRanges []TimeRangeModel `json:"timeRanges,omitempty" tfsdk:"time_ranges"`
+}
+
+// scheduleDays are the valid `days` values, indexed by time.Weekday.
+var scheduleDays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// parseTimeOfDay parses an "HH:MM" 24-hour time to minutes after midnight.
+func parseTimeOfDay(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil || len(value) != len("15:04") {
+		return 0, fmt.Errorf("%q is not a time of day in 24-hour \"HH:MM\" format", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// checkTimeRange validates a time range's bounds.
+func checkTimeRange(timeRange TimeRangeModel) error {
+	start, err := parseTimeOfDay(timeRange.Start)
+	if err != nil {
+		return err
+	}
+	end, err := parseTimeOfDay(timeRange.End)
+	if err != nil {
+		return err
+	}
+	if start == end {
+		return fmt.Errorf("time range %s–%s is empty; start and end must differ", timeRange.Start, timeRange.End)
+	}
+	return nil
+}
+
+// scheduleActive reports whether schedule includes the instant at. A time
+// range that spans midnight belongs to the day on which it starts.
+func scheduleActive(schedule ScheduleModel, at time.Time) (bool, error) {
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return false, fmt.Errorf("invalid schedule timezone %q: %w", schedule.Timezone, err)
+	}
+	local := at.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	yesterday := (today + 6) % 7
+	onDay := func(day time.Weekday) bool {
+		return len(schedule.Days) == 0 || slices.Contains(schedule.Days, scheduleDays[day])
+	}
+
+	if len(schedule.TimeRanges) == 0 {
+		return onDay(today), nil
+	}
+	for _, timeRange := range schedule.TimeRanges {
+		if err := checkTimeRange(timeRange); err != nil {
+			return false, err
+		}
+		start, _ := parseTimeOfDay(timeRange.Start)
+		end, _ := parseTimeOfDay(timeRange.End)
+		if start < end {
+			if onDay(today) && start <= minute && minute < end {
+				return true, nil
+			}
+			continue
+		}
+		// Spans midnight: the evening part belongs to today, the morning part
+		// to yesterday.
+		if (onDay(today) && minute >= start) || (onDay(yesterday) && minute < end) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type timezoneValidator struct{}
+
+func (v timezoneValidator) Description(_ context.Context) string {
+	return "value must be an IANA time zone name"
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	// LoadLocation accepts "" and "Local", which depend on the host.
+	if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timezone", fmt.Sprintf("%q is not an IANA time zone name, such as \"America/New_York\" or \"UTC\".", value))
+	}
+}
+
+type timeOfDayValidator struct{}
+
+func (v timeOfDayValidator) Description(_ context.Context) string {
+	return `value must be a time of day in 24-hour "HH:MM" format`
+}
+
+func (v timeOfDayValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeOfDayValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseTimeOfDay(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid time of day", err.Error()+".")
+	}
+}
+
+// nonEmptyTimeRange validates that a time range's start and end differ.
+type nonEmptyTimeRange struct{}
+
+func (v nonEmptyTimeRange) Description(_ context.Context) string {
+	return "`start` and `end` must differ"
+}
+
+func (v nonEmptyTimeRange) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v nonEmptyTimeRange) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	attributes := req.ConfigValue.Attributes()
+	start, startOk := attributes["start"].(types.String)
+	end, endOk := attributes["end"].(types.String)
+	if !startOk || !endOk || start.IsNull() || start.IsUnknown() || end.IsNull() || end.IsUnknown() {
+		return
+	}
+	timeRange := TimeRangeModel{Start: start.ValueString(), End: end.ValueString()}
+	// Malformed bounds are reported by timeOfDayValidator.
+	if _, err := parseTimeOfDay(timeRange.Start); err != nil {
+		return
+	}
+	if _, err := parseTimeOfDay(timeRange.End); err != nil {
+		return
+	}
+	if err := checkTimeRange(timeRange); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("end"), "Invalid time range", err.Error()+".")
+	}
+}
+
+// scheduleAttribute builds the `schedule` schema, which only exists at schema
+// version 5 and later.
+func scheduleAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: `If present, the policy only applies at the scheduled times; at other times, requests are routed as if the
+policy did not exist. Use a second policy without a schedule to route requests at other times (e.g., require group
+approval outside of business hours). At least one of 'days' or 'time_ranges' is required.`,
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: `The IANA time zone in which 'days' and 'time_ranges' are evaluated, e.g. "America/New_York" or "UTC".`,
+				Required:            true,
+				Validators:          []validator.String{timezoneValidator{}},
+			},
+			"days": schema.ListAttribute{
+				MarkdownDescription: `The days of the week on which the policy applies; if omitted, every day. Each is a lowercase day name, from 'monday' through 'sunday'.`,
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(scheduleDays...)),
+					// Only runs when `schedule` is present, unlike a validator on
+					// `schedule` itself.
+					listvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("time_ranges")),
+				},
+			},
+			"time_ranges": schema.ListNestedAttribute{
+				MarkdownDescription: `The times of day at which the policy applies; if omitted, all day. A range whose 'end' is not after its 'start'
+spans midnight, and belongs to the day on which it starts.`,
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"start": schema.StringAttribute{
+							MarkdownDescription: `The start of the range (inclusive), in 24-hour "HH:MM" format`,
+							Required:            true,
+							Validators:          []validator.String{timeOfDayValidator{}},
+						},
+						"end": schema.StringAttribute{
+							MarkdownDescription: `The end of the range (exclusive), in 24-hour "HH:MM" format`,
+							Required:            true,
+							Validators:          []validator.String{timeOfDayValidator{}},
+						},
+					},
+					Validators: []validator.Object{nonEmptyTimeRange{}},
+				},
+			},
+		},
+	}
+}
+
+// describeSchedule renders a schedule for evaluation results.
+func describeSchedule(schedule ScheduleModel) string {
+	days := "every day"
+	if len(schedule.Days) > 0 {
+		days = strings.Join(schedule.Days, ", ")
+	}
+	times := "all day"
+	if len(schedule.TimeRanges) > 0 {
+		ranges := make([]string, len(schedule.TimeRanges))
+		for i, timeRange := range schedule.TimeRanges {
+			ranges[i] = timeRange.Start + "–" + timeRange.End
+		}
+		times = strings.Join(ranges, ", ")
+	}
+	return fmt.Sprintf("%s, %s (%s)", days, times, schedule.Timezone)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	for value, want := range map[string]int{"00:00": 0, "09:30": 570, "23:59": 1439} {
+		if got, err := parseTimeOfDay(value); err != nil || got != want {
+			t.Errorf("parseTimeOfDay(%q) = %d, %v; want %d", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"9:30", "24:00", "12:60", "noon", "09:30:00", ""} {
+		if _, err := parseTimeOfDay(value); err == nil {
+			t.Errorf("parseTimeOfDay(%q) succeeded; want an error", value)
+		}
+	}
+}
+
+func TestScheduleActive(t *testing.T) {
+	businessHours := ScheduleModel{
+		Timezone:   "America/New_York",
+		Days:       []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+		TimeRanges: []TimeRangeModel{{Start: "09:00", End: "18:00"}},
+	}
+	// Friday night through Saturday morning.
+	onCall := ScheduleModel{
+		Timezone:   "UTC",
+		Days:       []string{"friday"},
+		TimeRanges: []TimeRangeModel{{Start: "22:00", End: "06:00"}},
+	}
+	weekends := ScheduleModel{Timezone: "UTC", Days: []string{"saturday", "sunday"}}
+
+	cases := []struct {
+		name     string
+		schedule ScheduleModel
+		at       string
+		want     bool
+	}{
+		// 2025-01-06 is a Monday.
+		{name: "within business hours", schedule: businessHours, at: "2025-01-06T14:00:00Z", want: true},
+		{name: "before business hours in the local timezone", schedule: businessHours, at: "2025-01-06T13:59:00Z"},
+		{name: "end is exclusive", schedule: businessHours, at: "2025-01-06T23:00:00Z"},
+		{name: "weekend", schedule: businessHours, at: "2025-01-11T15:00:00Z"},
+		{name: "overnight, evening part", schedule: onCall, at: "2025-01-10T23:00:00Z", want: true},
+		{name: "overnight, morning part", schedule: onCall, at: "2025-01-11T05:59:00Z", want: true},
+		{name: "overnight, morning of an unscheduled day", schedule: onCall, at: "2025-01-10T05:00:00Z"},
+		{name: "days only", schedule: weekends, at: "2025-01-12T03:00:00Z", want: true},
+		{name: "days only, weekday", schedule: weekends, at: "2025-01-13T03:00:00Z"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, c.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := scheduleActive(c.schedule, at)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != c.want {
+				t.Errorf("scheduleActive = %v; want %v", got, c.want)
+			}
+		})
+	}
+
+	if _, err := scheduleActive(ScheduleModel{Timezone: "Mars/Olympus_Mons"}, time.Now()); err == nil {
+		t.Error("scheduleActive with an invalid timezone succeeded; want an error")
+	}
+}
+
+// validationProvider serves p0_access_policy, so that tests can run the same
+// configuration validation as `terraform validate`.
+type validationProvider struct{}
+
+func (validationProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "p0"
+}
+
+func (validationProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+}
+
+func (validationProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+}
+
+func (validationProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{NewAccessPolicy}
+}
+
+func (validationProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// validateAccessPolicyConfig validates a p0_access_policy configuration with
+// the given attributes set, and all others null.
+func validateAccessPolicyConfig(t *testing.T, attributes map[string]any) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewAccessPolicy().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+	config := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		p := path.Empty()
+		for _, step := range strings.Split(name, ".") {
+			p = p.AtName(step)
+		}
+		if diags := config.SetAttribute(ctx, p, attributes[name]); diags.HasError() {
+			t.Fatalf("setting %s: %v", name, diags)
+		}
+	}
+	value, err := tfprotov6.NewDynamicValue(objectType, config.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := providerserver.NewProtocol6(validationProvider{})()
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{TypeName: "p0_access_policy", Config: &value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Logf("%s: %s: %s", d.Attribute, d.Summary, d.Detail)
+	}
+	return resp.Diagnostics
+}
+
+func TestScheduleValidation(t *testing.T) {
+	policy := map[string]any{
+		"name":           "policy",
+		"requestor.type": "any",
+		"resource.type":  "any",
+		"approval":       []string{},
+	}
+	if diags := validateAccessPolicyConfig(t, policy); len(diags) != 0 {
+		t.Errorf("policy without schedule: diagnostics = %v; want none", diags)
+	}
+
+	policy["schedule.timezone"] = "UTC"
+	diags := validateAccessPolicyConfig(t, policy)
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "time_ranges") {
+		t.Errorf("schedule without days or time_ranges: diagnostics = %v; want one naming time_ranges", diags)
+	}
+
+	policy["schedule.days"] = []string{"monday"}
+	if diags := validateAccessPolicyConfig(t, policy); len(diags) != 0 {
+		t.Errorf("schedule with days: diagnostics = %v; want none", diags)
+	}
+}
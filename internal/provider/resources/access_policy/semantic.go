@@ -0,0 +1,434 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// The P0 API may return a policy's groups, approval rules, and principal set
+// members in a different order than they were written, may re-label groups to
+// match the directory, and may re-case email addresses. None of these change
+// the policy's meaning, so the attributes holding them use the custom types
+// here, whose semantic equality lets the framework keep the prior (configured
+// or stored) value whenever the API's value is equal to it; otherwise every
+// such policy would plan a perpetual diff, and applies would fail with
+// inconsistent results.
+//
+// The equality functions are also used directly wherever policies are
+// compared outside of the framework (see conflicts.go and drift.go).
+
+// groupKey identifies a directory group. Labels are display-only.
+func groupKey(group GroupModelV1) string {
+	var directory, id string
+	if group.Directory != nil {
+		directory = *group.Directory
+	}
+	if group.Id != nil {
+		id = *group.Id
+	}
+	return directory + "/" + id
+}
+
+// groupKeys returns the sorted keys of groups.
+func groupKeys(groups []GroupModelV1) []string {
+	keys := make([]string, len(groups))
+	for i, group := range groups {
+		keys[i] = groupKey(group)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// groupsEqual reports whether two group lists contain the same groups,
+// ignoring order and labels.
+func groupsEqual(a []GroupModelV1, b []GroupModelV1) bool {
+	return slices.Equal(groupKeys(a), groupKeys(b))
+}
+
+// approvalEqual reports whether two approval rules are the same, ignoring
+// their groups' order and labels.
+func approvalEqual(a ApprovalModelV5, b ApprovalModelV5) bool {
+	if !groupsEqual(a.Groups, b.Groups) {
+		return false
+	}
+	a.Groups, b.Groups = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+// approvalsEqual reports whether two approval lists contain the same rules,
+// ignoring their order and their groups' order and labels. Within a stage,
+// approval rules are alternatives (except for 'deny', which always applies),
+// and each rule's stage is explicit, so their order doesn't matter.
+func approvalsEqual(a []ApprovalModelV5, b []ApprovalModelV5) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	// Since approvalEqual is an equivalence, matching each rule of a to any
+	// equal, unmatched rule of b is exact.
+	matched := make([]bool, len(b))
+	for _, x := range a {
+		found := false
+		for i, y := range b {
+			if !matched[i] && approvalEqual(x, y) {
+				matched[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// profileAttributesEqual reports whether two profile conditions match the
+// same values for the same attributes, ignoring the order of the values.
+func profileAttributesEqual(a map[string][]string, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, aValues := range a {
+		bValues, ok := b[name]
+		if !ok {
+			return false
+		}
+		aValues, bValues = slices.Clone(aValues), slices.Clone(bValues)
+		slices.Sort(aValues)
+		slices.Sort(bValues)
+		if !slices.Equal(aValues, bValues) {
+			return false
+		}
+	}
+	return true
+}
+
+// requestorsEqual reports whether two requestor rules are the same, ignoring
+// the order and labels of their groups and the order of their profile
+// values.
+func requestorsEqual(a *RequestorModelV5, b *RequestorModelV5) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	x, y := *a, *b
+	if !groupsEqual(x.Groups, y.Groups) {
+		return false
+	}
+	x.Groups, y.Groups = nil, nil
+	if x.Agent != nil && y.Agent != nil {
+		if !groupsEqual(x.Agent.Groups, y.Agent.Groups) {
+			return false
+		}
+		xAgent, yAgent := *x.Agent, *y.Agent
+		xAgent.Groups, yAgent.Groups = nil, nil
+		x.Agent, y.Agent = &xAgent, &yAgent
+	}
+	if x.User != nil && y.User != nil {
+		if !groupsEqual(x.User.Groups, y.User.Groups) {
+			return false
+		}
+		xUser, yUser := *x.User, *y.User
+		xUser.Groups, yUser.Groups = nil, nil
+		x.User, y.User = &xUser, &yUser
+	}
+	if x.Profile != nil && y.Profile != nil {
+		if x.Profile.Directory != y.Profile.Directory || !profileAttributesEqual(x.Profile.Attributes, y.Profile.Attributes) {
+			return false
+		}
+		x.Profile, y.Profile = nil, nil
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// semanticEqualityTypeError reports a value of an unexpected type passed to a
+// semantic equality check.
+func semanticEqualityTypeError(want attr.Value, got attr.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.AddError(
+		"Semantic Equality Check Error",
+		fmt.Sprintf("An unexpected value type was received while performing semantic equality checks. Expected %T, got %T.", want, got),
+	)
+	return diags
+}
+
+// GroupsType is the type of `groups` attributes. Its values are semantically
+// equal if they contain the same groups (see groupsEqual).
+type GroupsType struct {
+	basetypes.ListType
+}
+
+var _ basetypes.ListTypable = GroupsType{}
+
+func (t GroupsType) Equal(o attr.Type) bool {
+	other, ok := o.(GroupsType)
+	return ok && t.ListType.Equal(other.ListType)
+}
+
+func (t GroupsType) String() string {
+	return "GroupsType"
+}
+
+func (t GroupsType) ValueFromList(ctx context.Context, in basetypes.ListValue) (basetypes.ListValuable, diag.Diagnostics) {
+	return GroupsValue{ListValue: in}, nil
+}
+
+func (t GroupsType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.ListType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := value.(basetypes.ListValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return GroupsValue{ListValue: list}, nil
+}
+
+func (t GroupsType) ValueType(ctx context.Context) attr.Value {
+	return GroupsValue{}
+}
+
+// GroupsValue is a value of GroupsType.
+type GroupsValue struct {
+	basetypes.ListValue
+}
+
+var _ basetypes.ListValuableWithSemanticEquals = GroupsValue{}
+
+func (v GroupsValue) Type(ctx context.Context) attr.Type {
+	return GroupsType{ListType: basetypes.ListType{ElemType: v.ElementType(ctx)}}
+}
+
+func (v GroupsValue) Equal(o attr.Value) bool {
+	other, ok := o.(GroupsValue)
+	return ok && v.ListValue.Equal(other.ListValue)
+}
+
+func (v GroupsValue) ListSemanticEquals(ctx context.Context, priorValuable basetypes.ListValuable) (bool, diag.Diagnostics) {
+	prior, ok := priorValuable.(GroupsValue)
+	if !ok {
+		return false, semanticEqualityTypeError(v, priorValuable)
+	}
+	var diags diag.Diagnostics
+	var priorGroups, groups []GroupModelV1
+	diags.Append(prior.ElementsAs(ctx, &priorGroups, false)...)
+	diags.Append(v.ElementsAs(ctx, &groups, false)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	return groupsEqual(priorGroups, groups), diags
+}
+
+// ApprovalsType is the type of `approval` attributes. Its values are
+// semantically equal if they contain the same rules (see approvalsEqual).
+type ApprovalsType struct {
+	basetypes.ListType
+}
+
+var _ basetypes.ListTypable = ApprovalsType{}
+
+func (t ApprovalsType) Equal(o attr.Type) bool {
+	other, ok := o.(ApprovalsType)
+	return ok && t.ListType.Equal(other.ListType)
+}
+
+func (t ApprovalsType) String() string {
+	return "ApprovalsType"
+}
+
+func (t ApprovalsType) ValueFromList(ctx context.Context, in basetypes.ListValue) (basetypes.ListValuable, diag.Diagnostics) {
+	return ApprovalsValue{ListValue: in}, nil
+}
+
+func (t ApprovalsType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.ListType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := value.(basetypes.ListValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return ApprovalsValue{ListValue: list}, nil
+}
+
+func (t ApprovalsType) ValueType(ctx context.Context) attr.Value {
+	return ApprovalsValue{}
+}
+
+// ApprovalsValue is a value of ApprovalsType.
+type ApprovalsValue struct {
+	basetypes.ListValue
+}
+
+var _ basetypes.ListValuableWithSemanticEquals = ApprovalsValue{}
+
+func (v ApprovalsValue) Type(ctx context.Context) attr.Type {
+	return ApprovalsType{ListType: basetypes.ListType{ElemType: v.ElementType(ctx)}}
+}
+
+func (v ApprovalsValue) Equal(o attr.Value) bool {
+	other, ok := o.(ApprovalsValue)
+	return ok && v.ListValue.Equal(other.ListValue)
+}
+
+func (v ApprovalsValue) ListSemanticEquals(ctx context.Context, priorValuable basetypes.ListValuable) (bool, diag.Diagnostics) {
+	prior, ok := priorValuable.(ApprovalsValue)
+	if !ok {
+		return false, semanticEqualityTypeError(v, priorValuable)
+	}
+	var diags diag.Diagnostics
+	var priorApprovals, approvals []ApprovalModelV5
+	diags.Append(prior.ElementsAs(ctx, &priorApprovals, false)...)
+	diags.Append(v.ElementsAs(ctx, &approvals, false)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	return approvalsEqual(priorApprovals, approvals), diags
+}
+
+// UsersType is the type of `users` attributes. Its values are semantically
+// equal if they contain the same email addresses (see usersEqual).
+type UsersType struct {
+	basetypes.ListType
+}
+
+var _ basetypes.ListTypable = UsersType{}
+
+func (t UsersType) Equal(o attr.Type) bool {
+	other, ok := o.(UsersType)
+	return ok && t.ListType.Equal(other.ListType)
+}
+
+func (t UsersType) String() string {
+	return "UsersType"
+}
+
+func (t UsersType) ValueFromList(ctx context.Context, in basetypes.ListValue) (basetypes.ListValuable, diag.Diagnostics) {
+	return UsersValue{ListValue: in}, nil
+}
+
+func (t UsersType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.ListType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := value.(basetypes.ListValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return UsersValue{ListValue: list}, nil
+}
+
+func (t UsersType) ValueType(ctx context.Context) attr.Value {
+	return UsersValue{}
+}
+
+// UsersValue is a value of UsersType.
+type UsersValue struct {
+	basetypes.ListValue
+}
+
+var _ basetypes.ListValuableWithSemanticEquals = UsersValue{}
+
+func (v UsersValue) Type(ctx context.Context) attr.Type {
+	return UsersType{ListType: basetypes.ListType{ElemType: v.ElementType(ctx)}}
+}
+
+func (v UsersValue) Equal(o attr.Value) bool {
+	other, ok := o.(UsersValue)
+	return ok && v.ListValue.Equal(other.ListValue)
+}
+
+func (v UsersValue) ListSemanticEquals(ctx context.Context, priorValuable basetypes.ListValuable) (bool, diag.Diagnostics) {
+	prior, ok := priorValuable.(UsersValue)
+	if !ok {
+		return false, semanticEqualityTypeError(v, priorValuable)
+	}
+	var diags diag.Diagnostics
+	var priorUsers, users []string
+	diags.Append(prior.ElementsAs(ctx, &priorUsers, false)...)
+	diags.Append(v.ElementsAs(ctx, &users, false)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	return usersEqual(priorUsers, users), diags
+}
+
+// ProfileAttributesType is the type of requestor profile `attributes`. Its
+// values are semantically equal if they match the same values (see
+// profileAttributesEqual).
+type ProfileAttributesType struct {
+	basetypes.MapType
+}
+
+var _ basetypes.MapTypable = ProfileAttributesType{}
+
+func (t ProfileAttributesType) Equal(o attr.Type) bool {
+	other, ok := o.(ProfileAttributesType)
+	return ok && t.MapType.Equal(other.MapType)
+}
+
+func (t ProfileAttributesType) String() string {
+	return "ProfileAttributesType"
+}
+
+func (t ProfileAttributesType) ValueFromMap(ctx context.Context, in basetypes.MapValue) (basetypes.MapValuable, diag.Diagnostics) {
+	return ProfileAttributesValue{MapValue: in}, nil
+}
+
+func (t ProfileAttributesType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.MapType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := value.(basetypes.MapValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return ProfileAttributesValue{MapValue: m}, nil
+}
+
+func (t ProfileAttributesType) ValueType(ctx context.Context) attr.Value {
+	return ProfileAttributesValue{}
+}
+
+// ProfileAttributesValue is a value of ProfileAttributesType.
+type ProfileAttributesValue struct {
+	basetypes.MapValue
+}
+
+var _ basetypes.MapValuableWithSemanticEquals = ProfileAttributesValue{}
+
+func (v ProfileAttributesValue) Type(ctx context.Context) attr.Type {
+	return ProfileAttributesType{MapType: basetypes.MapType{ElemType: v.ElementType(ctx)}}
+}
+
+func (v ProfileAttributesValue) Equal(o attr.Value) bool {
+	other, ok := o.(ProfileAttributesValue)
+	return ok && v.MapValue.Equal(other.MapValue)
+}
+
+func (v ProfileAttributesValue) MapSemanticEquals(ctx context.Context, priorValuable basetypes.MapValuable) (bool, diag.Diagnostics) {
+	prior, ok := priorValuable.(ProfileAttributesValue)
+	if !ok {
+		return false, semanticEqualityTypeError(v, priorValuable)
+	}
+	var diags diag.Diagnostics
+	var priorAttributes, attributes map[string][]string
+	diags.Append(prior.ElementsAs(ctx, &priorAttributes, false)...)
+	diags.Append(v.ElementsAs(ctx, &attributes, false)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	return profileAttributesEqual(priorAttributes, attributes), diags
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package accesspolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func group(id string, label string) GroupModelV1 {
+	return GroupModelV1{Directory: strPtr("okta"), Id: strPtr(id), Label: strPtr(label)}
+}
+
+func TestGroupsEqual(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []GroupModelV1
+		want bool
+	}{
+		{name: "reordered", a: []GroupModelV1{group("1", "A"), group("2", "B")}, b: []GroupModelV1{group("2", "B"), group("1", "A")}, want: true},
+		{name: "relabeled", a: []GroupModelV1{group("1", "A")}, b: []GroupModelV1{group("1", "Renamed")}, want: true},
+		{name: "null and empty", a: nil, b: []GroupModelV1{}, want: true},
+		{name: "different id", a: []GroupModelV1{group("1", "A")}, b: []GroupModelV1{group("2", "A")}},
+		{name: "different directory", a: []GroupModelV1{group("1", "A")}, b: []GroupModelV1{{Directory: strPtr("workspace"), Id: strPtr("1"), Label: strPtr("A")}}},
+		{name: "duplicate", a: []GroupModelV1{group("1", "A")}, b: []GroupModelV1{group("1", "A"), group("1", "A")}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := groupsEqual(c.a, c.b); got != c.want {
+				t.Errorf("groupsEqual = %v; want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestApprovalsEqual(t *testing.T) {
+	keep := "keep"
+	approvals := []ApprovalModelV5{
+		{Type: "group", Groups: []GroupModelV1{group("3", "Security"), group("4", "Admins")}, Effect: &keep},
+		{Type: "p0"},
+	}
+	cases := []struct {
+		name string
+		b    []ApprovalModelV5
+		want bool
+	}{
+		{name: "reordered and relabeled", b: []ApprovalModelV5{
+			{Type: "p0"},
+			{Type: "group", Groups: []GroupModelV1{group("4", "Administrators"), group("3", "Security")}, Effect: &keep},
+		}, want: true},
+		{name: "different rule", b: []ApprovalModelV5{{Type: "p0"}, {Type: "deny"}}},
+		{name: "different groups", b: []ApprovalModelV5{
+			{Type: "group", Groups: []GroupModelV1{group("3", "Security")}, Effect: &keep},
+			{Type: "p0"},
+		}},
+		{name: "duplicated rule", b: []ApprovalModelV5{{Type: "p0"}, {Type: "p0"}}},
+		{name: "fewer rules", b: []ApprovalModelV5{{Type: "p0"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := approvalsEqual(approvals, c.b); got != c.want {
+				t.Errorf("approvalsEqual = %v; want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRequestorsEqual(t *testing.T) {
+	keep := "keep"
+	requestor := &RequestorModelV5{
+		Type:    "profile",
+		Effect:  &keep,
+		Profile: &RequestorProfileModel{Directory: "okta", Attributes: map[string][]string{"department": {"eng", "ops"}}},
+	}
+	cases := []struct {
+		name string
+		b    *RequestorModelV5
+		want bool
+	}{
+		{name: "reordered values", b: &RequestorModelV5{
+			Type:    "profile",
+			Effect:  &keep,
+			Profile: &RequestorProfileModel{Directory: "okta", Attributes: map[string][]string{"department": {"ops", "eng"}}},
+		}, want: true},
+		{name: "different values", b: &RequestorModelV5{
+			Type:    "profile",
+			Effect:  &keep,
+			Profile: &RequestorProfileModel{Directory: "okta", Attributes: map[string][]string{"department": {"eng"}}},
+		}},
+		{name: "different type", b: &RequestorModelV5{Type: "any"}},
+		{name: "nil", b: nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := requestorsEqual(requestor, c.b); got != c.want {
+				t.Errorf("requestorsEqual = %v; want %v", got, c.want)
+			}
+		})
+	}
+
+	agentic := func(groups ...GroupModelV1) *RequestorModelV5 {
+		return &RequestorModelV5{Type: "agentic", Agent: &AgentModel{Type: "owner-group", Groups: groups, Effect: &keep}, User: &AgenticUserModel{Type: "none"}}
+	}
+	if !requestorsEqual(agentic(group("1", "A"), group("2", "B")), agentic(group("2", "Renamed"), group("1", "A"))) {
+		t.Error("requestorsEqual = false for reordered and relabeled agent owner groups; want true")
+	}
+	if requestorsEqual(agentic(group("1", "A")), agentic(group("2", "A"))) {
+		t.Error("requestorsEqual = true for different agent owner groups; want false")
+	}
+}
+
+// TestSemanticTypes verifies that the current schema's groups, approval rules,
+// and profile conditions use the semantic equality types, and that their
+// values are semantically equal exactly when the equality functions say so.
+func TestSemanticTypes(t *testing.T) {
+	ctx := context.Background()
+	policySchema := newAccessPolicySchema(currentSchemaVersion)
+	requestor := policySchema.Attributes["requestor"].(schema.SingleNestedAttribute)
+
+	groupsType, ok := requestor.Attributes["groups"].GetType().(GroupsType)
+	if !ok {
+		t.Fatalf("requestor.groups type = %T; want GroupsType", requestor.Attributes["groups"].GetType())
+	}
+	groupsValue := func(groups ...GroupModelV1) GroupsValue {
+		list, diags := types.ListValueFrom(ctx, groupsType.ElemType, groups)
+		if diags.HasError() {
+			t.Fatalf("ListValueFrom: %v", diags)
+		}
+		return GroupsValue{ListValue: list}
+	}
+	prior := groupsValue(group("1", "Eng"), group("2", "Ops"))
+	if equal, diags := groupsValue(group("2", "Operations"), group("1", "Eng")).ListSemanticEquals(ctx, prior); diags.HasError() || !equal {
+		t.Errorf("GroupsValue.ListSemanticEquals(reordered and relabeled) = %v, %v; want true", equal, diags)
+	}
+	if equal, diags := groupsValue(group("1", "Eng")).ListSemanticEquals(ctx, prior); diags.HasError() || equal {
+		t.Errorf("GroupsValue.ListSemanticEquals(changed) = %v, %v; want false", equal, diags)
+	}
+
+	approvalType, ok := policySchema.Attributes["approval"].GetType().(ApprovalsType)
+	if !ok {
+		t.Fatalf("approval type = %T; want ApprovalsType", policySchema.Attributes["approval"].GetType())
+	}
+	keep := "keep"
+	approvalsValue := func(approvals ...ApprovalModelV5) ApprovalsValue {
+		list, diags := types.ListValueFrom(ctx, approvalType.ElemType, approvals)
+		if diags.HasError() {
+			t.Fatalf("ListValueFrom: %v", diags)
+		}
+		return ApprovalsValue{ListValue: list}
+	}
+	priorApprovals := approvalsValue(
+		ApprovalModelV5{Type: "group", Groups: []GroupModelV1{group("3", "Security"), group("4", "Admins")}, Effect: &keep},
+		ApprovalModelV5{Type: "p0"},
+	)
+	reordered := approvalsValue(
+		ApprovalModelV5{Type: "p0"},
+		ApprovalModelV5{Type: "group", Groups: []GroupModelV1{group("4", "Admins"), group("3", "Security")}, Effect: &keep},
+	)
+	if equal, diags := reordered.ListSemanticEquals(ctx, priorApprovals); diags.HasError() || !equal {
+		t.Errorf("ApprovalsValue.ListSemanticEquals(reordered) = %v, %v; want true", equal, diags)
+	}
+	changed := approvalsValue(ApprovalModelV5{Type: "p0"}, ApprovalModelV5{Type: "deny"})
+	if equal, diags := changed.ListSemanticEquals(ctx, priorApprovals); diags.HasError() || equal {
+		t.Errorf("ApprovalsValue.ListSemanticEquals(changed) = %v, %v; want false", equal, diags)
+	}
+
+	profile := requestor.Attributes["profile"].(schema.SingleNestedAttribute)
+	attributesType, ok := profile.Attributes["attributes"].GetType().(ProfileAttributesType)
+	if !ok {
+		t.Fatalf("requestor.profile.attributes type = %T; want ProfileAttributesType", profile.Attributes["attributes"].GetType())
+	}
+	attributesValue := func(attributes map[string][]string) ProfileAttributesValue {
+		m, diags := types.MapValueFrom(ctx, attributesType.ElemType, attributes)
+		if diags.HasError() {
+			t.Fatalf("MapValueFrom: %v", diags)
+		}
+		return ProfileAttributesValue{MapValue: m}
+	}
+	priorAttributes := attributesValue(map[string][]string{"department": {"eng", "ops"}})
+	if equal, diags := attributesValue(map[string][]string{"department": {"ops", "eng"}}).MapSemanticEquals(ctx, priorAttributes); diags.HasError() || !equal {
+		t.Errorf("ProfileAttributesValue.MapSemanticEquals(reordered) = %v, %v; want true", equal, diags)
+	}
+	if equal, diags := attributesValue(map[string][]string{"department": {"eng"}}).MapSemanticEquals(ctx, priorAttributes); diags.HasError() || equal {
+		t.Errorf("ProfileAttributesValue.MapSemanticEquals(changed) = %v, %v; want false", equal, diags)
+	}
+}
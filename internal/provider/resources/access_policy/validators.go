@@ -6,6 +6,7 @@ package accesspolicy
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -128,3 +129,125 @@ func (v exclusiveToType) ValidateObject(_ context.Context, req validator.ObjectR
 		}
 	}
 }
+
+// approvalRuleConstraints validates the approval rule attributes that only
+// apply to some rule types: `stage` may not be set on 'deny' rules (which
+// always apply), and `options.min_approvers` may only be set on rules that
+// people satisfy.
+type approvalRuleConstraints struct{}
+
+// ApprovalRuleConstraints returns an object validator for an approval rule's
+// `stage` and `options.min_approvers`.
+func ApprovalRuleConstraints() validator.Object {
+	return approvalRuleConstraints{}
+}
+
+func (v approvalRuleConstraints) Description(_ context.Context) string {
+	return "`stage` and `options.min_approvers` may only be used with some values of `type`"
+}
+
+func (v approvalRuleConstraints) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v approvalRuleConstraints) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+
+	typeValue, ok := attributes["type"].(types.String)
+	if !ok || typeValue.IsNull() || typeValue.IsUnknown() {
+		return
+	}
+	ruleType := typeValue.ValueString()
+
+	if stage, ok := attributes["stage"]; ok && ruleType == "deny" && !stage.IsNull() && !stage.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("stage"),
+			"Attribute not allowed",
+			"`stage` may not be used when `type` is \"deny\"; 'deny' rules apply regardless of stage.",
+		)
+	}
+
+	options, ok := attributes["options"].(types.Object)
+	if !ok || options.IsNull() || options.IsUnknown() || slices.Contains(quorumApprovalTypes, ruleType) {
+		return
+	}
+	if minApprovers, ok := options.Attributes()["min_approvers"]; ok && !minApprovers.IsNull() && !minApprovers.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("options").AtName("min_approvers"),
+			"Attribute not allowed",
+			fmt.Sprintf("`min_approvers` may only be used when `type` is one of %s; it is not valid when `type` is %q.", quotedList(quorumApprovalTypes), ruleType),
+		)
+	}
+}
+
+// checkApprovalStages verifies that stages, where 0 stands for an omitted
+// stage (i.e., 1), are numbered consecutively from 1.
+func checkApprovalStages(stages []int64) error {
+	present := map[int64]bool{}
+	var last int64
+	for _, stage := range stages {
+		if stage == 0 {
+			stage = 1
+		}
+		present[stage] = true
+		last = max(last, stage)
+	}
+	for stage := int64(1); stage < last; stage++ {
+		if !present[stage] {
+			return fmt.Errorf("stage %d has no approval rules, but stage %d does; stages must be numbered consecutively from 1", stage, last)
+		}
+	}
+	return nil
+}
+
+// consecutiveApprovalStages validates that the approval rules' stages are
+// numbered consecutively from 1, so that no stage can never be reached.
+type consecutiveApprovalStages struct{}
+
+// ConsecutiveApprovalStages returns a list validator for the stages of an
+// `approval` list; see checkApprovalStages.
+func ConsecutiveApprovalStages() validator.List {
+	return consecutiveApprovalStages{}
+}
+
+func (v consecutiveApprovalStages) Description(_ context.Context) string {
+	return "approval stages must be numbered consecutively from 1"
+}
+
+func (v consecutiveApprovalStages) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v consecutiveApprovalStages) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var stages []int64
+	for _, element := range req.ConfigValue.Elements() {
+		rule, ok := element.(types.Object)
+		if !ok || rule.IsUnknown() {
+			// The stages can't all be known yet; allow it through to apply time.
+			return
+		}
+		if rule.IsNull() {
+			continue
+		}
+		// 'deny' rules don't belong to a stage.
+		if ruleType, ok := rule.Attributes()["type"].(types.String); ok && ruleType.ValueString() == "deny" {
+			continue
+		}
+		stage, ok := rule.Attributes()["stage"].(types.Int64)
+		if !ok || stage.IsUnknown() {
+			return
+		}
+		stages = append(stages, stage.ValueInt64())
+	}
+	if err := checkApprovalStages(stages); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid approval stages", err.Error()+".")
+	}
+}
@@ -442,3 +442,83 @@ func TestAgentAttributeDocumentsAgentClient(t *testing.T) {
 		t.Errorf("`client_id` description does not note the deprecated 'mcp-client' alias:\n%s", clientIdAttr.MarkdownDescription)
 	}
 }
+
+func TestCheckApprovalStages(t *testing.T) {
+	cases := []struct {
+		stages  []int64
+		wantErr bool
+	}{
+		{stages: nil},
+		{stages: []int64{0, 0}},
+		{stages: []int64{2, 1, 0}},
+		{stages: []int64{1, 3}, wantErr: true},
+		{stages: []int64{2}, wantErr: true},
+	}
+	for _, c := range cases {
+		if err := checkApprovalStages(c.stages); (err != nil) != c.wantErr {
+			t.Errorf("checkApprovalStages(%v) = %v; want error %v", c.stages, err, c.wantErr)
+		}
+	}
+}
+
+// TestApprovalRuleConstraints verifies `stage` is rejected on 'deny' rules
+// and `options.min_approvers` on rules that aren't satisfied by people.
+func TestApprovalRuleConstraints(t *testing.T) {
+	cases := []struct {
+		name    string
+		rule    ApprovalModelV5
+		wantErr bool
+	}{
+		{name: "staged group quorum passes", rule: ApprovalModelV5{Type: "group", Stage: ptr(int64(2)), Options: &ApprovalOptionsModelV2{MinApprovers: ptr(int64(2))}}},
+		{name: "p0 quorum passes", rule: ApprovalModelV5{Type: "p0", Options: &ApprovalOptionsModelV2{MinApprovers: ptr(int64(2))}}},
+		{name: "auto quorum errors", rule: ApprovalModelV5{Type: "auto", Options: &ApprovalOptionsModelV2{MinApprovers: ptr(int64(2))}}, wantErr: true},
+		{name: "staged deny errors", rule: ApprovalModelV5{Type: "deny", Stage: ptr(int64(1))}, wantErr: true},
+		{name: "deny passes", rule: ApprovalModelV5{Type: "deny"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			object, diags := types.ObjectValueFrom(context.Background(), approvalObjectType.AttrTypes, c.rule)
+			if diags.HasError() {
+				t.Fatalf("failed to build object: %v", diags)
+			}
+			resp := &validator.ObjectResponse{}
+			ApprovalRuleConstraints().ValidateObject(
+				context.Background(),
+				validator.ObjectRequest{Path: path.Root("approval").AtListIndex(0), ConfigValue: object},
+				resp,
+			)
+			if got := resp.Diagnostics.HasError(); got != c.wantErr {
+				t.Errorf("HasError() = %v; want %v (%v)", got, c.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestConsecutiveApprovalStages(t *testing.T) {
+	cases := []struct {
+		name    string
+		rules   []ApprovalModelV5
+		wantErr bool
+	}{
+		{name: "unstaged", rules: []ApprovalModelV5{{Type: "p0"}, {Type: "deny"}}},
+		{name: "two stages", rules: []ApprovalModelV5{{Type: "requestor-profile"}, {Type: "p0", Stage: ptr(int64(2))}}},
+		{name: "skipped stage", rules: []ApprovalModelV5{{Type: "p0"}, {Type: "p0", Stage: ptr(int64(3))}}, wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			list, diags := types.ListValueFrom(context.Background(), approvalObjectType, c.rules)
+			if diags.HasError() {
+				t.Fatalf("failed to build list: %v", diags)
+			}
+			resp := &validator.ListResponse{}
+			ConsecutiveApprovalStages().ValidateList(
+				context.Background(),
+				validator.ListRequest{Path: path.Root("approval"), ConfigValue: list},
+				resp,
+			)
+			if got := resp.Diagnostics.HasError(); got != c.wantErr {
+				t.Errorf("HasError() = %v; want %v (%v)", got, c.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
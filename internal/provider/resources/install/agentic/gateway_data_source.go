@@ -0,0 +1,117 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package installagentic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p0-security/terraform-provider-p0/internal"
+	"github.com/p0-security/terraform-provider-p0/internal/common"
+	installresources "github.com/p0-security/terraform-provider-p0/internal/provider/resources/install"
+)
+
+var _ datasource.DataSource = &GatewayDataSource{}
+var _ datasource.DataSourceWithConfigure = &GatewayDataSource{}
+
+func NewGatewayDataSource() datasource.DataSource {
+	return &GatewayDataSource{}
+}
+
+type GatewayDataSource struct {
+	data *internal.P0ProviderData
+}
+
+type gatewayDataSourceModel struct {
+	Id                  string       `tfsdk:"id"`
+	Url                 types.String `tfsdk:"url"`
+	OauthEndpoint       types.String `tfsdk:"oauth_endpoint"`
+	LogProjectId        types.String `tfsdk:"log_project_id"`
+	ServiceAccountEmail types.String `tfsdk:"service_account_email"`
+	State               types.String `tfsdk:"state"`
+}
+
+// componentPath returns the API path of a single item of an Agentic component,
+// matching the path common.Install uses for the resources in this package.
+func componentPath(component string, id string) string {
+	return fmt.Sprintf("integrations/%s/config/%s/%s", IntegrationKey, component, url.PathEscape(id))
+}
+
+func (d *GatewayDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agentic_gateway"
+}
+
+func (d *GatewayDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `An installed Agentic gateway.
+
+Use this data source to reference a gateway managed in another workspace (e.g. to register servers against it, or
+to configure agents with its URL and OAuth endpoint).`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The gateway's unique identifier",
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Agentic gateway URL; servers are hosted here",
+			},
+			"oauth_endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "OAuth server endpoint of the gateway",
+			},
+			"log_project_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "GCP project ID where this gateway's Cloud Logging entries land, if configured",
+			},
+			"service_account_email": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Email address of the service account identity that P0 uses to communicate with the gateway",
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: common.StateMarkdownDescription,
+			},
+		},
+	}
+}
+
+func (d *GatewayDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	data := internal.ConfigureDataSource(&req, resp)
+	if data != nil {
+		d.data = data
+	}
+}
+
+func (d *GatewayDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model gatewayDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var json gatewayApi
+	httpResp, err := d.data.Get(componentPath(installresources.Gateway, model.Id), &json)
+	if httpResp != nil && httpResp.StatusCode == 404 {
+		resp.Diagnostics.AddError("Agentic gateway not found", fmt.Sprintf("No Agentic gateway with id %q is installed.", model.Id))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to read Agentic gateway, got error:\n%s", err))
+		return
+	}
+
+	model.Url = types.StringValue(json.Item.Url)
+	model.OauthEndpoint = types.StringValue(json.Item.OauthEndpoint)
+	model.LogProjectId = types.StringPointerValue(json.Item.LogProjectId)
+	model.ServiceAccountEmail = types.StringPointerValue(json.Item.ServiceAccountEmail)
+	model.State = types.StringValue(json.Item.State)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/p0-security/terraform-provider-p0/internal"
 	"github.com/p0-security/terraform-provider-p0/internal/common"
@@ -92,11 +93,13 @@ func (r *IdentityProvider) Schema(ctx context.Context, req resource.SchemaReques
 			},
 			"audience_pattern": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Pattern that a token's audience (the `aud` claim) must match to be accepted",
+				MarkdownDescription: "Pattern that a token's audience (the `aud` claim) must match to be accepted. " + common.PatternSyntaxDescription,
+				Validators:          []validator.String{common.PatternValidator()},
 			},
 			"subject_pattern": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Pattern that a token's subject (the `sub` claim) must match to be accepted (omit to accept any subject)",
+				MarkdownDescription: "Pattern that a token's subject (the `sub` claim) must match to be accepted (omit to accept any subject). " + common.PatternSyntaxDescription,
+				Validators:          []validator.String{common.PatternValidator()},
 			},
 			"dynamic_registration": schema.BoolAttribute{
 				Optional: true,
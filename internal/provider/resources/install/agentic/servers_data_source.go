@@ -0,0 +1,237 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package installagentic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p0-security/terraform-provider-p0/internal"
+	"github.com/p0-security/terraform-provider-p0/internal/common"
+	installresources "github.com/p0-security/terraform-provider-p0/internal/provider/resources/install"
+)
+
+var _ datasource.DataSource = &ServersDataSource{}
+var _ datasource.DataSourceWithConfigure = &ServersDataSource{}
+
+func NewServersDataSource() datasource.DataSource {
+	return &ServersDataSource{}
+}
+
+type ServersDataSource struct {
+	data *internal.P0ProviderData
+}
+
+// serverDataModel reuses p0_agentic_server's credential and definition models,
+// whose tfsdk tags already match the nested schema below.
+type serverDataModel struct {
+	Id         string                `tfsdk:"id"`
+	Gateway    string                `tfsdk:"gateway"`
+	State      string                `tfsdk:"state"`
+	Credential serverCredentialModel `tfsdk:"credential"`
+	Definition serverDefinitionModel `tfsdk:"definition"`
+}
+
+type serversDataSourceModel struct {
+	Gateway types.String      `tfsdk:"gateway"`
+	Servers []serverDataModel `tfsdk:"servers"`
+}
+
+// agenticConfigApi is the shape of the full Agentic integration config, keyed
+// by component and then by item id. Components are left undecoded, since only
+// the servers are read here and the other components have different shapes.
+type agenticConfigApi struct {
+	Config map[string]json.RawMessage `json:"config"`
+}
+
+// servers decodes the server component of the config, keyed by server id.
+func (c agenticConfigApi) servers() (map[string]serverJson, error) {
+	raw, ok := c.Config[installresources.Server]
+	if !ok {
+		return nil, nil
+	}
+	var servers map[string]serverJson
+	if err := json.Unmarshal(raw, &servers); err != nil {
+		return nil, err
+	}
+	return servers, nil
+}
+
+func (d *ServersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agentic_servers"
+}
+
+func (d *ServersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `MCP servers registered behind Agentic gateways.
+
+Use this data source to reference servers registered in another workspace, for example to build agent access
+policies from their identifiers.`,
+		Attributes: map[string]schema.Attribute{
+			"gateway": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "If set, only servers hosted by the gateway with this `id` are returned",
+			},
+			"servers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching servers, ordered by `id`",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The server's unique identifier",
+						},
+						"gateway": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The `id` of the gateway that hosts this server",
+						},
+						"state": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: common.StateMarkdownDescription,
+						},
+						"credential": schema.SingleNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The server's credential source",
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "One of 'aws', 'gcp', or 'oauth'.",
+								},
+								"provider": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "The `id` of the federation-provider identity, if 'type' is 'aws' or 'gcp'.",
+								},
+								"grant": schema.SingleNestedAttribute{
+									Computed:            true,
+									MarkdownDescription: "The OAuth grant used to obtain the credential, if 'type' is 'oauth'.",
+									Attributes: map[string]schema.Attribute{
+										"type": schema.StringAttribute{
+											Computed:            true,
+											MarkdownDescription: "The OAuth grant type.",
+										},
+										"pkce": schema.BoolAttribute{
+											Computed:            true,
+											MarkdownDescription: "Whether Proof Key for Code Exchange (PKCE) is used.",
+										},
+										"client_id": schema.StringAttribute{
+											Computed:            true,
+											MarkdownDescription: "OAuth client identifier registered with the upstream provider.",
+										},
+									},
+								},
+							},
+						},
+						"definition": schema.SingleNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether this server is predefined by P0, or a custom server definition",
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "One of 'p0' or 'custom'.",
+								},
+								"id": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "Pre-defined server identifier, if 'type' is 'p0'.",
+								},
+								"hosting": schema.SingleNestedAttribute{
+									Computed:            true,
+									MarkdownDescription: "How a custom server is hosted, if 'type' is 'custom'.",
+									Attributes: map[string]schema.Attribute{
+										"type": schema.StringAttribute{
+											Computed:            true,
+											MarkdownDescription: "One of 'container' or 'external'.",
+										},
+										"entrypoint": schema.StringAttribute{
+											Computed:            true,
+											MarkdownDescription: "Container run entrypoint, if 'type' is 'container'.",
+										},
+										"image": schema.StringAttribute{
+											Computed:            true,
+											MarkdownDescription: "Image that hosts the MCP server, if 'type' is 'container'.",
+										},
+										"url": schema.StringAttribute{
+											Computed:            true,
+											MarkdownDescription: "URL of the externally hosted MCP server, if 'type' is 'external'.",
+										},
+										"label": schema.StringAttribute{
+											Computed:            true,
+											MarkdownDescription: "Human-friendly label for this server, if 'type' is 'external'.",
+										},
+									},
+								},
+								"logo_url": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "An address of a logo image for a custom server.",
+								},
+								"prompt": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "Text used to describe a custom server to agents.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ServersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	data := internal.ConfigureDataSource(&req, resp)
+	if data != nil {
+		d.data = data
+	}
+}
+
+// filterServers converts the API's server items to data-source models,
+// keeping only those hosted by gateway (when non-nil), ordered by id so that
+// the result is stable across reads.
+func filterServers(items map[string]serverJson, gateway *string) []serverDataModel {
+	servers := []serverDataModel{}
+	for id, item := range items {
+		if gateway != nil && item.Gateway != *gateway {
+			continue
+		}
+		servers = append(servers, serverDataModel{
+			Id:         id,
+			Gateway:    item.Gateway,
+			State:      item.State,
+			Credential: item.Credential,
+			Definition: item.Definition,
+		})
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Id < servers[j].Id })
+	return servers
+}
+
+func (d *ServersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model serversDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config agenticConfigApi
+	httpResp, err := d.data.Get(fmt.Sprintf("integrations/%s/config", IntegrationKey), &config)
+	// An uninstalled integration simply has no servers.
+	if err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to read Agentic servers, got error:\n%s", err))
+		return
+	}
+
+	servers, err := config.servers()
+	if err != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to decode Agentic servers, got error:\n%s", err))
+		return
+	}
+	model.Servers = filterServers(servers, model.Gateway.ValueStringPointer())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package installagentic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFilterServers(t *testing.T) {
+	items := map[string]serverJson{
+		"zeta":  {Gateway: "primary", State: "installed", Definition: serverDefinitionModel{Type: "custom"}},
+		"alpha": {Gateway: "primary", State: "installed", Credential: serverCredentialModel{Type: "aws"}},
+		"beta":  {Gateway: "secondary", State: "stage"},
+	}
+	primary := "primary"
+	missing := "missing"
+
+	cases := []struct {
+		name    string
+		gateway *string
+		want    []string
+	}{
+		{name: "no filter returns all servers ordered by id", gateway: nil, want: []string{"alpha", "beta", "zeta"}},
+		{name: "filter keeps only the gateway's servers", gateway: &primary, want: []string{"alpha", "zeta"}},
+		{name: "unknown gateway returns an empty list", gateway: &missing, want: []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := filterServers(items, tc.gateway)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d servers, want %d", len(got), len(tc.want))
+			}
+			for i, id := range tc.want {
+				if got[i].Id != id {
+					t.Errorf("servers[%d].Id = %q, want %q", i, got[i].Id, id)
+				}
+			}
+		})
+	}
+
+	got := filterServers(items, &primary)
+	if got[0].Credential.Type != "aws" || got[1].Definition.Type != "custom" {
+		t.Errorf("credential and definition not carried through: %+v", got)
+	}
+	if filterServers(nil, nil) == nil {
+		t.Error("expected an empty, non-nil list when no servers are installed")
+	}
+}
+
+// TestAgenticConfigServers verifies that components with other shapes don't
+// prevent the servers from being decoded.
+func TestAgenticConfigServers(t *testing.T) {
+	var config agenticConfigApi
+	body := `{"config": {
+		"gateway": {"primary": {"state": "installed", "hostname": "mcp.example.com"}},
+		"identity-provider": ["not", "an", "object"],
+		"server": {"alpha": {"gateway": "primary", "state": "installed"}}
+	}}`
+	if err := json.Unmarshal([]byte(body), &config); err != nil {
+		t.Fatalf("unmarshal config: %v", err)
+	}
+	servers, err := config.servers()
+	if err != nil {
+		t.Fatalf("servers: %v", err)
+	}
+	if len(servers) != 1 || servers["alpha"].Gateway != "primary" {
+		t.Errorf("servers = %+v; want only alpha on primary", servers)
+	}
+
+	servers, err = agenticConfigApi{}.servers()
+	if err != nil || servers != nil {
+		t.Errorf("servers() with no server component = %v, %v; want nil, nil", servers, err)
+	}
+}
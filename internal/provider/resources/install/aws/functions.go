@@ -0,0 +1,297 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package installaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &RoleArnFunction{}
+var _ function.Function = &MergeTrustPolicyFunction{}
+
+// awsRoleNameRegex matches an IAM role name, optionally prefixed by its path.
+var awsRoleNameRegex = regexp.MustCompile(`^[\w+=,.@/-]+$`)
+
+// defaultPolicyVersion is the IAM policy language version used when the
+// P0-issued trust policy does not specify one.
+const defaultPolicyVersion = "2012-10-17"
+
+// Argument positions of the functions below.
+const (
+	partitionArg   = 0
+	accountIdArg   = 1
+	roleNameArg    = 2
+	trustPolicyArg = 0
+	statementsArg  = 1
+)
+
+// argumentError is an error caused by the value of a single function argument.
+type argumentError struct {
+	argument int64
+	err      error
+}
+
+func (e argumentError) Error() string {
+	return e.err.Error()
+}
+
+func (e argumentError) Unwrap() error {
+	return e.err
+}
+
+// argumentErrorf returns an argumentError for the given argument position.
+func argumentErrorf(argument int64, format string, a ...any) error {
+	return argumentError{argument: argument, err: fmt.Errorf(format, a...)}
+}
+
+// funcError converts err to a function error, attributed to its argument if it
+// is an argumentError.
+func funcError(err error) *function.FuncError {
+	var argErr argumentError
+	if errors.As(err, &argErr) {
+		return function.NewArgumentFuncError(argErr.argument, err.Error())
+	}
+	return function.NewFuncError(err.Error())
+}
+
+// roleArn returns the ARN of an IAM role. roleName may include the role's path
+// (e.g. "service-role/P0Role").
+func roleArn(partition string, accountId string, roleName string) (string, error) {
+	if !AwsPartitionRegex.MatchString(partition) {
+		return "", argumentErrorf(partitionArg, "AWS partition must be one of: aws, aws-us-gov; got %q", partition)
+	}
+	if !AwsAccountIdRegex.MatchString(accountId) {
+		return "", argumentErrorf(accountIdArg, "AWS account IDs should consist of 12 numeric digits; got %q", accountId)
+	}
+	name := strings.TrimPrefix(roleName, "/")
+	if !awsRoleNameRegex.MatchString(name) {
+		return "", argumentErrorf(roleNameArg, "invalid AWS role name %q", roleName)
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountId, name), nil
+}
+
+// decodeJson decodes s into a generic value, preserving numbers verbatim.
+func decodeJson(s string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected content after JSON value")
+	}
+	return value, nil
+}
+
+// statementsOf returns the statements of a policy document, a statement array,
+// or a single statement.
+func statementsOf(value any) ([]any, error) {
+	switch v := value.(type) {
+	case []any:
+		return v, nil
+	case map[string]any:
+		statement, ok := v["Statement"]
+		if !ok {
+			// A lone statement object.
+			return []any{v}, nil
+		}
+		switch s := statement.(type) {
+		case []any:
+			return s, nil
+		case map[string]any:
+			return []any{s}, nil
+		}
+		return nil, fmt.Errorf("policy `Statement` must be an object or an array")
+	}
+	return nil, fmt.Errorf("expected a policy document, a statement, or an array of statements")
+}
+
+// canonicalJson encodes value with sorted object keys and no insignificant
+// whitespace, so that semantically equal statements encode identically.
+func canonicalJson(value any) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// mergeTrustPolicy appends the additional statements to the P0-issued trust
+// policy. P0's statements come first, in their original order, followed by
+// the additional statements in theirs; statements identical to one already
+// present are dropped, and a statement whose `Sid` is already used by a
+// different statement is an error. The result is canonical JSON, so equal
+// inputs always render identically.
+func mergeTrustPolicy(trustPolicy string, additional string) (string, error) {
+	base, err := decodeJson(trustPolicy)
+	if err != nil {
+		return "", argumentErrorf(trustPolicyArg, "invalid trust policy JSON: %w", err)
+	}
+	policy, ok := base.(map[string]any)
+	if !ok {
+		return "", argumentErrorf(trustPolicyArg, "trust policy must be a JSON object")
+	}
+	statements, err := statementsOf(policy)
+	if err != nil {
+		return "", argumentErrorf(trustPolicyArg, "invalid trust policy: %w", err)
+	}
+
+	extra, err := decodeJson(additional)
+	if err != nil {
+		return "", argumentErrorf(statementsArg, "invalid statements JSON: %w", err)
+	}
+	extraStatements, err := statementsOf(extra)
+	if err != nil {
+		return "", argumentErrorf(statementsArg, "invalid statements: %w", err)
+	}
+
+	merged := make([]any, 0, len(statements)+len(extraStatements))
+	seen := map[string]bool{}
+	sids := map[string]bool{}
+	for i, statement := range append(statements, extraStatements...) {
+		// Errors are attributed to the argument the offending statement came from.
+		argument := int64(trustPolicyArg)
+		if i >= len(statements) {
+			argument = statementsArg
+		}
+		object, ok := statement.(map[string]any)
+		if !ok {
+			return "", argumentErrorf(argument, "statement %d must be a JSON object", i)
+		}
+		encoded, err := canonicalJson(object)
+		if err != nil {
+			return "", err
+		}
+		if seen[encoded] {
+			continue
+		}
+		if sid, ok := object["Sid"].(string); ok && sid != "" {
+			if sids[sid] {
+				return "", argumentErrorf(argument, "statement Sid %q is used by more than one distinct statement", sid)
+			}
+			sids[sid] = true
+		}
+		seen[encoded] = true
+		merged = append(merged, object)
+	}
+
+	if _, ok := policy["Version"]; !ok {
+		policy["Version"] = defaultPolicyVersion
+	}
+	policy["Statement"] = merged
+	return canonicalJson(policy)
+}
+
+func NewRoleArnFunction() function.Function {
+	return &RoleArnFunction{}
+}
+
+// RoleArnFunction builds the ARN of the IAM role P0 uses in an AWS account.
+type RoleArnFunction struct{}
+
+func (f *RoleArnFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aws_role_arn"
+}
+
+func (f *RoleArnFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the ARN of an AWS IAM role",
+		MarkdownDescription: `Returns the ARN of an AWS IAM role, e.g. from the ` + "`partition`" + `, ` + "`id`" + `, and ` + "`role.name`" + ` attributes of
+` + "`p0_aws_iam_write_staged`" + `. The ARN uses the given partition, so roles in AWS GovCloud ('aws-us-gov') are
+rendered correctly.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "partition",
+				MarkdownDescription: "The AWS partition (aws or aws-us-gov).",
+			},
+			function.StringParameter{
+				Name:                "account_id",
+				MarkdownDescription: "The 12-digit AWS account ID.",
+			},
+			function.StringParameter{
+				Name:                "role_name",
+				MarkdownDescription: "The IAM role name, optionally prefixed by its path.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RoleArnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var partition, accountId, roleName string
+	resp.Error = req.Arguments.Get(ctx, &partition, &accountId, &roleName)
+	if resp.Error != nil {
+		return
+	}
+
+	arn, err := roleArn(partition, accountId, roleName)
+	if err != nil {
+		resp.Error = funcError(err)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, arn))
+}
+
+func NewMergeTrustPolicyFunction() function.Function {
+	return &MergeTrustPolicyFunction{}
+}
+
+// MergeTrustPolicyFunction merges additional statements into a P0-issued
+// trust policy.
+type MergeTrustPolicyFunction struct{}
+
+func (f *MergeTrustPolicyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_trust_policy"
+}
+
+func (f *MergeTrustPolicyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Merges additional statements into a P0-issued AWS trust policy",
+		MarkdownDescription: `Appends statements to a trust policy issued by P0 (e.g. the ` + "`role.trust_policy`" + ` attribute of
+` + "`p0_aws_iam_write_staged`" + `), returning the merged policy as JSON.
+
+P0's statements are kept first, in order, followed by the additional statements. Statements identical to one
+already present are dropped, and reusing a statement ` + "`Sid`" + ` for a different statement is an error. The output is
+canonical JSON, so the same inputs always produce the same string and do not cause spurious diffs.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "trust_policy",
+				MarkdownDescription: "The P0-issued trust policy JSON.",
+			},
+			function.StringParameter{
+				Name:                "statements",
+				MarkdownDescription: "JSON for the statements to add: a policy document, a single statement, or an array of statements.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *MergeTrustPolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var trustPolicy, statements string
+	resp.Error = req.Arguments.Get(ctx, &trustPolicy, &statements)
+	if resp.Error != nil {
+		return
+	}
+
+	merged, err := mergeTrustPolicy(trustPolicy, statements)
+	if err != nil {
+		resp.Error = funcError(err)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, merged))
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package installaws
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoleArn(t *testing.T) {
+	cases := []struct {
+		partition string
+		accountId string
+		roleName  string
+		want      string
+		wantErr   bool
+	}{
+		{"aws", "123456789012", "P0RoleIamManager", "arn:aws:iam::123456789012:role/P0RoleIamManager", false},
+		{"aws-us-gov", "123456789012", "P0RoleIamManager", "arn:aws-us-gov:iam::123456789012:role/P0RoleIamManager", false},
+		{"aws", "123456789012", "/service-role/P0Role", "arn:aws:iam::123456789012:role/service-role/P0Role", false},
+		{"aws-cn", "123456789012", "P0Role", "", true},
+		{"aws", "12345", "P0Role", "", true},
+		{"aws", "123456789012", "", "", true},
+		{"aws", "123456789012", "P0 Role", "", true},
+	}
+	for _, c := range cases {
+		got, err := roleArn(c.partition, c.accountId, c.roleName)
+		if (err != nil) != c.wantErr {
+			t.Errorf("roleArn(%q, %q, %q) error = %v; want error: %t", c.partition, c.accountId, c.roleName, err, c.wantErr)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("roleArn(%q, %q, %q) = %q; want %q", c.partition, c.accountId, c.roleName, got, c.want)
+		}
+	}
+}
+
+const p0TrustPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "P0",
+      "Effect": "Allow",
+      "Principal": {"Federated": "accounts.google.com"},
+      "Action": "sts:AssumeRoleWithWebIdentity",
+      "Condition": {"StringEquals": {"accounts.google.com:aud": "101234567890123456789"}}
+    }
+  ]
+}`
+
+func TestMergeTrustPolicy(t *testing.T) {
+	cases := []struct {
+		name       string
+		statements string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "appends a single statement after P0's",
+			statements: `{"Sid": "Ci", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "sts:AssumeRole"}`,
+			want:       `{"Statement":[{"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringEquals":{"accounts.google.com:aud":"101234567890123456789"}},"Effect":"Allow","Principal":{"Federated":"accounts.google.com"},"Sid":"P0"},{"Action":"sts:AssumeRole","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Sid":"Ci"}],"Version":"2012-10-17"}`,
+		},
+		{
+			name:       "accepts a policy document and drops duplicates",
+			statements: `{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Sid": "P0", "Action": "sts:AssumeRoleWithWebIdentity", "Principal": {"Federated": "accounts.google.com"}, "Condition": {"StringEquals": {"accounts.google.com:aud": "101234567890123456789"}}}}`,
+			want:       `{"Statement":[{"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringEquals":{"accounts.google.com:aud":"101234567890123456789"}},"Effect":"Allow","Principal":{"Federated":"accounts.google.com"},"Sid":"P0"}],"Version":"2012-10-17"}`,
+		},
+		{
+			name:       "empty array leaves the policy unchanged",
+			statements: `[]`,
+			want:       `{"Statement":[{"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringEquals":{"accounts.google.com:aud":"101234567890123456789"}},"Effect":"Allow","Principal":{"Federated":"accounts.google.com"},"Sid":"P0"}],"Version":"2012-10-17"}`,
+		},
+		{
+			name:       "conflicting Sid is an error",
+			statements: `[{"Sid": "P0", "Effect": "Deny", "Principal": "*", "Action": "sts:AssumeRole"}]`,
+			wantErr:    `Sid "P0"`,
+		},
+		{
+			name:       "invalid JSON is an error",
+			statements: `[{"Sid": }]`,
+			wantErr:    "invalid statements JSON",
+		},
+		{
+			name:       "non-object statement is an error",
+			statements: `["sts:AssumeRole"]`,
+			wantErr:    "statement 1 must be a JSON object",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := mergeTrustPolicy(p0TrustPolicy, c.statements)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("error = %v; want it to contain %q", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != c.want {
+				t.Errorf("mergeTrustPolicy() =\n%s\nwant\n%s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMergeTrustPolicyDefaultsVersion(t *testing.T) {
+	got, err := mergeTrustPolicy(`{"Statement": []}`, `[]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `{"Statement":[],"Version":"2012-10-17"}`; got != want {
+		t.Errorf("mergeTrustPolicy() = %s; want %s", got, want)
+	}
+}
+
+// TestFuncErrorArgument verifies that validation errors are attributed to the
+// offending argument.
+func TestFuncErrorArgument(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want int64
+	}{
+		{name: "partition", err: func() error { _, err := roleArn("aws-cn-x", "123456789012", "P0Role"); return err }(), want: partitionArg},
+		{name: "account id", err: func() error { _, err := roleArn("aws", "1234", "P0Role"); return err }(), want: accountIdArg},
+		{name: "role name", err: func() error { _, err := roleArn("aws", "123456789012", "P0 Role"); return err }(), want: roleNameArg},
+		{name: "trust policy", err: func() error { _, err := mergeTrustPolicy(`[]`, `[]`); return err }(), want: trustPolicyArg},
+		{name: "statements", err: func() error { _, err := mergeTrustPolicy(p0TrustPolicy, `[1]`); return err }(), want: statementsArg},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			funcErr := funcError(c.err)
+			if funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != c.want {
+				t.Errorf("funcError(%v).FunctionArgument = %v; want %d", c.err, funcErr.FunctionArgument, c.want)
+			}
+		})
+	}
+}
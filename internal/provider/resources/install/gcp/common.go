@@ -107,7 +107,7 @@ func permissions(name string) schema.ListAttribute {
 	return schema.ListAttribute{
 		ElementType: types.StringType,
 		Computed:    true,
-		MarkdownDescription: `Permissions that should be granted to P0 via the custom role, described in the 'role' attribute,
+		MarkdownDescription: `Permissions that should be granted to P0 via the custom role, described in the 'custom_role' attribute,
 in order to install projects for ` + name,
 	}
 }
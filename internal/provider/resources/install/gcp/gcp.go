@@ -2,6 +2,7 @@ package installgcp
 
 import (
 	"context"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -76,6 +77,52 @@ func (r *Gcp) Metadata(ctx context.Context, req resource.MetadataRequest, resp *
 	resp.TypeName = req.ProviderTypeName + "_gcp"
 }
 
+// gcpMetadataAttributes are the read-only attributes of p0_gcp that come from
+// the integration's metadata. The p0_gcp_permissions data source exposes the
+// same attributes.
+func gcpMetadataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"access_logs": schema.SingleNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: `Read-only attributes used to configure infrastructure and IAM grants for access-logs integrations`,
+			Attributes: map[string]schema.Attribute{
+				"permissions": permissions("access logging"),
+				"custom_role": customRole,
+			},
+		},
+		"iam_assessment": schema.SingleNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: `Read-only attributes used to configure IAM grants for IAM-assessment integrations`,
+			Attributes: map[string]schema.Attribute{
+				"permissions": schema.SingleNestedAttribute{
+					Computed:            true,
+					MarkdownDescription: `Permissions that must be granted to P0's service account`,
+					Attributes: map[string]schema.Attribute{
+						"project": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: `Permissions required for project-level IAM-assessment installs`,
+						},
+						"organization": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: `Permissions, in addition to 'project' permissions, required for organization-level IAM-assessment installs`,
+						},
+					},
+				},
+			},
+		},
+		"org_wide_policy": schema.SingleNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: `Read-only attributes used to configure IAM grants for org-wide policy-read installation`,
+			Attributes: map[string]schema.Attribute{
+				"permissions": permissions("org-wide policy-read installation"),
+				"custom_role": customRole,
+			},
+		},
+	}
+}
+
 func (r *Gcp) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `A Google Cloud installation.`,
@@ -94,46 +141,9 @@ func (r *Gcp) Schema(ctx context.Context, req resource.SchemaRequest, resp *reso
 				Computed:            true,
 				MarkdownDescription: `The identity that P0 uses to communicate with your Google Cloud organization`,
 			},
-			"access_logs": schema.SingleNestedAttribute{
-				Computed:            true,
-				MarkdownDescription: `Read-only attributes used to configure infrastructure and IAM grants for access-logs integrations`,
-				Attributes: map[string]schema.Attribute{
-					"permissions": permissions("access logging"),
-					"custom_role": customRole,
-				},
-			},
-			"iam_assessment": schema.SingleNestedAttribute{
-				Computed:            true,
-				MarkdownDescription: `Read-only attributes used to configure IAM grants for IAM-assessment integrations`,
-				Attributes: map[string]schema.Attribute{
-					"permissions": schema.SingleNestedAttribute{
-						Computed:            true,
-						MarkdownDescription: `Permissions that must be granted to P0's service account`,
-						Attributes: map[string]schema.Attribute{
-							"project": schema.ListAttribute{
-								Computed:            true,
-								ElementType:         types.StringType,
-								MarkdownDescription: `Permissions required for project-level IAM-assessment installs`,
-							},
-							"organization": schema.ListAttribute{
-								Computed:            true,
-								ElementType:         types.StringType,
-								MarkdownDescription: `Permissions, in addition to 'project' permissions, required for organization-level IAM-assessment installs`,
-							},
-						},
-					},
-				},
-			},
-			"org_wide_policy": schema.SingleNestedAttribute{
-				Computed:            true,
-				MarkdownDescription: `Read-only attributes used to configure IAM grants for org-wide policy-read installation`,
-				Attributes: map[string]schema.Attribute{
-					"permissions": permissions("org-wide policy-read installation"),
-					"custom_role": customRole,
-				},
-			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, gcpMetadataAttributes())
 }
 
 func (r *Gcp) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -0,0 +1,132 @@
+package installgcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GcpPermissions{}
+var _ datasource.DataSourceWithConfigure = &GcpPermissions{}
+
+func NewGcpPermissions() datasource.DataSource {
+	return &GcpPermissions{}
+}
+
+// GcpPermissions exposes the permission sets and custom-role definitions that
+// P0 returns as metadata for the Google Cloud integration, independently of
+// the p0_gcp resource, so that roles can be created and kept in sync without
+// managing the installation in the same configuration.
+//
+// P0 defines these values, and the provider has no copy of them, so they are
+// only known once the integration is installed. Until then, the data source
+// reads as not installed rather than failing, so that configurations can plan
+// before P0 is installed.
+type GcpPermissions struct {
+	data *internal.P0ProviderData
+}
+
+// The metadata structs already carry tfsdk tags matching the p0_gcp schema,
+// so they double as this data source's nested models.
+// Each is nil until the integration is installed.
+type gcpPermissionsModel struct {
+	Installed     bool                   `tfsdk:"installed"`
+	AccessLogs    *gcpAccessLogsMetadata `tfsdk:"access_logs"`
+	IamAssessment *struct {
+		Permissions gcpIamAssessmentMetadata `tfsdk:"permissions"`
+	} `tfsdk:"iam_assessment"`
+	OrgWidePolicy *gcpPermissionsMetadata `tfsdk:"org_wide_policy"`
+}
+
+// dataSourceAttribute converts one of p0_gcp's read-only attributes to its
+// data source equivalent, so that both share a single definition.
+func dataSourceAttribute(attribute resourceschema.Attribute) (schema.Attribute, error) {
+	switch a := attribute.(type) {
+	case resourceschema.StringAttribute:
+		return schema.StringAttribute{Computed: true, MarkdownDescription: a.MarkdownDescription}, nil
+	case resourceschema.ListAttribute:
+		return schema.ListAttribute{Computed: true, ElementType: a.ElementType, MarkdownDescription: a.MarkdownDescription}, nil
+	case resourceschema.SingleNestedAttribute:
+		attributes := make(map[string]schema.Attribute, len(a.Attributes))
+		for name, nested := range a.Attributes {
+			converted, err := dataSourceAttribute(nested)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			attributes[name] = converted
+		}
+		return schema.SingleNestedAttribute{Computed: true, MarkdownDescription: a.MarkdownDescription, Attributes: attributes}, nil
+	}
+	return nil, fmt.Errorf("unsupported attribute type %T", attribute)
+}
+
+func (d *GcpPermissions) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gcp_permissions"
+}
+
+func (d *GcpPermissions) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{}
+	for name, attribute := range gcpMetadataAttributes() {
+		converted, err := dataSourceAttribute(attribute)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid schema", fmt.Sprintf("Unable to build the %q attribute: %s", name, err))
+			return
+		}
+		attributes[name] = converted
+	}
+	attributes["installed"] = schema.BoolAttribute{
+		Computed:            true,
+		MarkdownDescription: "Whether the organization-level `p0_gcp` installation exists. If false, every other attribute is null.",
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The Google Cloud permissions and custom roles that P0 requires.
+
+These are the same values exposed by the read-only attributes of ` + "`p0_gcp`" + `. Use this data source to create
+and update P0's custom roles in a configuration that does not manage the P0 installation itself, for example an
+organization-bootstrap module that runs before P0 is installed on any project.
+
+P0 defines these values, and only reports them once the organization-level ` + "`p0_gcp`" + ` installation exists;
+that installation grants P0 no access by itself. Until then, ` + "`installed`" + ` is false and the other attributes are
+null, so a configuration can plan before P0 is installed: create roles only when ` + "`installed`" + ` is true.`,
+		Attributes: attributes,
+	}
+}
+
+func (d *GcpPermissions) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	data := internal.ConfigureDataSource(&req, resp)
+	if data != nil {
+		d.data = data
+	}
+}
+
+func (d *GcpPermissions) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var json gcpApi
+	httpResponse, err := d.data.Get(fmt.Sprintf("integrations/%s/config", GcpKey), &json)
+	if httpResponse != nil && httpResponse.StatusCode == 404 {
+		tflog.Debug(ctx, "Google Cloud integration not installed (404), reading no permissions")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &gcpPermissionsModel{})...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to read Google Cloud permissions, got error:\n%s", err))
+		return
+	}
+
+	model := gcpPermissionsModel{
+		Installed:     true,
+		AccessLogs:    &json.Metadata.AccessLogs,
+		OrgWidePolicy: &json.Metadata.OrgWidePolicy,
+	}
+	model.IamAssessment = &struct {
+		Permissions gcpIamAssessmentMetadata `tfsdk:"permissions"`
+	}{Permissions: json.Metadata.IamAssessment}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
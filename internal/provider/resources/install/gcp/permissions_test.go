@@ -0,0 +1,88 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package installgcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+const gcpConfigResponse = `{
+	"config": {"root": {"_": {"organizationId": "123456789012", "serviceAccountEmail": "p0@example.iam.gserviceaccount.com"}}},
+	"metadata": {
+		"access-logs": {"requiredPermissions": ["logging.sinks.create"], "customRole": {"id": "p0AccessLogs", "name": "P0 access logs"}},
+		"iam-assessment": {"requiredPermissions": ["iam.roles.list"], "orgLevelPermissions": ["resourcemanager.folders.list"]},
+		"org-wide-policy": {"requiredPermissions": ["iam.policies.get"], "customRole": {"id": "p0OrgPolicy", "name": "P0 org policy"}}
+	}
+}`
+
+func readGcpPermissions(t *testing.T, status int, body string) (gcpPermissionsModel, *datasource.ReadResponse) {
+	t.Helper()
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/integrations/"+GcpKey+"/config" {
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	source := &GcpPermissions{data: &internal.P0ProviderData{BaseUrl: server.URL, Authentication: "Bearer x", Client: server.Client()}}
+	var schemaResp datasource.SchemaResponse
+	source.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema: %v", schemaResp.Diagnostics)
+	}
+	resp := &datasource.ReadResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	source.Read(ctx, datasource.ReadRequest{}, resp)
+
+	var model gcpPermissionsModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &model)...)
+	}
+	return model, resp
+}
+
+func TestGcpPermissionsRead(t *testing.T) {
+	model, resp := readGcpPermissions(t, http.StatusOK, gcpConfigResponse)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if !model.Installed {
+		t.Error("installed = false; want true")
+	}
+	if !slices.Equal(model.AccessLogs.Permissions, []string{"logging.sinks.create"}) || model.AccessLogs.CustomRole.Id != "p0AccessLogs" {
+		t.Errorf("access_logs = %+v", model.AccessLogs)
+	}
+	if !slices.Equal(model.IamAssessment.Permissions.OrganizationPermissions, []string{"resourcemanager.folders.list"}) {
+		t.Errorf("iam_assessment = %+v", model.IamAssessment)
+	}
+	if model.OrgWidePolicy.CustomRole.Name != "P0 org policy" {
+		t.Errorf("org_wide_policy = %+v", model.OrgWidePolicy)
+	}
+}
+
+// TestGcpPermissionsReadNotInstalled verifies that the data source reads as
+// not installed, rather than failing, before P0 is installed.
+func TestGcpPermissionsReadNotInstalled(t *testing.T) {
+	model, resp := readGcpPermissions(t, http.StatusNotFound, `{"error": "Not found"}`)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if model.Installed || model.AccessLogs != nil || model.IamAssessment != nil || model.OrgWidePolicy != nil {
+		t.Errorf("model = %+v; want not installed, with null permissions", model)
+	}
+}
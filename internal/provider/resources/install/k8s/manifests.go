@@ -0,0 +1,576 @@
+// Copyright (c) 2024 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package installk8s
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p0-security/terraform-provider-p0/internal"
+	installresources "github.com/p0-security/terraform-provider-p0/internal/provider/resources/install"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &KubernetesManifests{}
+var _ datasource.DataSourceWithConfigure = &KubernetesManifests{}
+
+// The namespace, the service account secret, the proxy deployment and its
+// p0-files path follow the p0_kubernetes install instructions (see
+// examples/resources/p0_kubernetes). The admission controller's port, TLS
+// path, webhook path and rules, and the proxy's arguments are not documented
+// there, so they are inputs, copied from the install instructions in the P0
+// app.
+const (
+	defaultNamespace          = "p0-security"
+	defaultBraekhusVolumeSize = "10Mi"
+	// The proxy writes its key pair, including jwk.public.json, here.
+	braekhusFilesPath = "/p0-files"
+	// Kubernetes calls webhook services on port 443 by default.
+	webhookServicePort = 443
+	// Containers run as this user and group, regardless of the image's USER,
+	// so that runAsNonRoot holds and the proxy can write its key pair volume.
+	nonRootId = 65532
+
+	serviceAccountName       = "p0-service-account"
+	serviceAccountSecretName = "p0-service-account-secret"
+	admissionControllerName  = "p0-admission-controller"
+	braekhusName             = "p0-braekhus-proxy"
+	// The proxy only forwards requests, authenticated with P0's own token, to
+	// the API server, so its service account has no role bindings.
+	braekhusServiceAccountName = "p0-braekhus"
+	braekhusKeysName           = "p0-braekhus-keys"
+)
+
+func NewKubernetesManifests() datasource.DataSource {
+	return &KubernetesManifests{}
+}
+
+// KubernetesManifests renders the in-cluster components for a staged
+// Kubernetes integration from the PKI values P0 generated while staging.
+type KubernetesManifests struct {
+	data *internal.P0ProviderData
+}
+
+type kubernetesManifestsModel struct {
+	Id                       string        `tfsdk:"id"`
+	Namespace                types.String  `tfsdk:"namespace"`
+	AdmissionControllerImage types.String  `tfsdk:"admission_controller_image"`
+	BraekhusImage            types.String  `tfsdk:"braekhus_image"`
+	BraekhusStorageClass     types.String  `tfsdk:"braekhus_storage_class"`
+	BraekhusArgs             []string      `tfsdk:"braekhus_args"`
+	AdmissionControllerPort  int64         `tfsdk:"admission_controller_port"`
+	AdmissionControllerTls   string        `tfsdk:"admission_controller_tls_path"`
+	WebhookPath              string        `tfsdk:"webhook_path"`
+	WebhookRules             []webhookRule `tfsdk:"webhook_rules"`
+	Manifests                types.List    `tfsdk:"manifests"`
+	Yaml                     types.String  `tfsdk:"yaml"`
+}
+
+// webhookRule is one of the RBAC operations the admission controller reviews.
+type webhookRule struct {
+	ApiGroups   []string `tfsdk:"api_groups"`
+	ApiVersions []string `tfsdk:"api_versions"`
+	Operations  []string `tfsdk:"operations"`
+	Resources   []string `tfsdk:"resources"`
+}
+
+// manifestParams holds everything needed to render the in-cluster components;
+// it is kept separate from the Terraform model so rendering is a pure function.
+type manifestParams struct {
+	Namespace                string
+	ConnectivityType         string
+	CaBundle                 string
+	ServerCert               string
+	ServerKey                string
+	AdmissionControllerImage string
+	BraekhusImage            string
+	// BraekhusStorageClass is the storage class of the proxy's key pair
+	// volume; if empty, the cluster's default.
+	BraekhusStorageClass string
+	BraekhusArgs         []string
+	// The admission controller serves its webhook over TLS on this port, with
+	// the key pair from AdmissionControllerTls.
+	AdmissionControllerPort int64
+	AdmissionControllerTls  string
+	WebhookPath             string
+	WebhookRules            []webhookRule
+}
+
+// pinnedImageValidator requires a container image reference to be pinned to
+// a digest or to a tag other than 'latest', so that re-applying the manifests
+// never silently changes the running version.
+type pinnedImageValidator struct{}
+
+var _ validator.String = pinnedImageValidator{}
+
+func (v pinnedImageValidator) Description(ctx context.Context) string {
+	return "must be pinned to a digest or to a tag other than 'latest'"
+}
+
+func (v pinnedImageValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v pinnedImageValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if !isPinnedImage(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Unpinned container image",
+			fmt.Sprintf("The image %q %s, e.g. 'p0security/braekhus:1.2.3' or 'p0security/braekhus@sha256:...'.", req.ConfigValue.ValueString(), v.Description(ctx)),
+		)
+	}
+}
+
+// isPinnedImage reports whether image names a digest, or a tag other than
+// 'latest'. A ':' before the last '/' belongs to a registry port, not a tag.
+func isPinnedImage(image string) bool {
+	if _, digest, ok := strings.Cut(image, "@"); ok {
+		return strings.HasPrefix(digest, "sha256:")
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, ok := strings.Cut(name, ":")
+	return ok && tag != "" && tag != "latest"
+}
+
+func (d *KubernetesManifests) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_manifests"
+}
+
+func (d *KubernetesManifests) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Kubernetes manifests for the in-cluster components of a staged K8s integration.
+
+Renders the P0 namespace, service account and token secret, RBAC bindings, admission controller (deployment, service,
+TLS secret and validating webhook), and, when the staged cluster uses 'proxy' connectivity, the braekhus proxy
+deployment. PKI values are read from the corresponding 'p0_kubernetes_staged' resource, which must be applied first.
+The admission controller's port, TLS path, and webhook path and rules, and the proxy's arguments, are not rendered
+from defaults; copy them from the Kubernetes install instructions in the P0 app.
+
+Containers run as a non-root user (uid ` + "`65532`" + `), without privilege escalation, on a read-only root filesystem.
+
+Each entry of 'manifests' is a single Kubernetes object encoded as JSON, suitable for
+` + "`kubernetes_manifest`" + ` (via ` + "`jsondecode`" + `) or ` + "`kubectl_manifest`" + `. Both 'manifests' and 'yaml' embed
+the admission controller's private key, so they are sensitive.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: `The display name of the staged cluster (the 'id' of its 'p0_kubernetes_staged' resource)`,
+			},
+			"namespace": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The namespace in which P0's components are deployed (defaults to `" + defaultNamespace + "`)",
+			},
+			"admission_controller_image": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The admission controller container image, pinned to a version tag or digest, e.g. `p0security/p0-admission-controller:<version>`",
+				Validators:          []validator.String{pinnedImageValidator{}},
+			},
+			"braekhus_image": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The braekhus proxy container image, pinned to a version tag or digest, e.g. `p0security/braekhus:<version>`. Required if the staged cluster uses 'proxy' connectivity.",
+				Validators:          []validator.String{pinnedImageValidator{}},
+			},
+			"braekhus_storage_class": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The storage class of the volume that keeps the braekhus proxy's key pair across restarts (defaults to the cluster's default storage class)",
+			},
+			"braekhus_args": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The braekhus proxy's container arguments, from the P0 app's install instructions. Its key pair is kept in `" + braekhusFilesPath + "`. Required if the staged cluster uses 'proxy' connectivity.",
+			},
+			"admission_controller_port": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The container port on which the admission controller serves its webhook, from the P0 app's install instructions",
+				Validators:          []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"admission_controller_tls_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The directory from which the admission controller reads `tls.crt` and `tls.key`, from the P0 app's install instructions",
+			},
+			"webhook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The HTTPS path of the admission controller's validating webhook, from the P0 app's install instructions",
+			},
+			"webhook_rules": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The operations the validating webhook reviews, from the P0 app's install instructions",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"api_groups":   schema.ListAttribute{Required: true, ElementType: types.StringType, MarkdownDescription: "The API groups of the reviewed resources"},
+						"api_versions": schema.ListAttribute{Required: true, ElementType: types.StringType, MarkdownDescription: "The API versions of the reviewed resources"},
+						"operations":   schema.ListAttribute{Required: true, ElementType: types.StringType, MarkdownDescription: "The reviewed operations, e.g. `CREATE`"},
+						"resources":    schema.ListAttribute{Required: true, ElementType: types.StringType, MarkdownDescription: "The reviewed resources"},
+					},
+				},
+				Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"manifests": schema.ListAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: `The rendered Kubernetes objects, one JSON document per entry, in apply order. Sensitive, since the TLS secret holds the admission controller's private key.`,
+			},
+			"yaml": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "All rendered objects as a single multi-document YAML stream, suitable for `kubectl apply -f`. Sensitive, since the TLS secret holds the admission controller's private key.",
+			},
+		},
+	}
+}
+
+func (d *KubernetesManifests) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	data := internal.ConfigureDataSource(&req, resp)
+	if data != nil {
+		d.data = data
+	}
+}
+
+func stringOrDefault(value types.String, defaultValue string) string {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return defaultValue
+	}
+	return value.ValueString()
+}
+
+func (d *KubernetesManifests) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model kubernetesManifestsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var json awsKubernetesStagedApi
+	configPath := fmt.Sprintf("integrations/%s/config/%s/%s", K8s, installresources.IamWrite, url.PathEscape(model.Id))
+	httpResp, err := d.data.Get(configPath, &json)
+	if httpResp != nil && httpResp.StatusCode == 404 {
+		resp.Diagnostics.AddError(
+			"Kubernetes cluster not staged",
+			fmt.Sprintf("No staged Kubernetes integration named %q was found. Apply its 'p0_kubernetes_staged' resource first.", model.Id),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to read staged Kubernetes integration, got error:\n%s", err))
+		return
+	}
+
+	if json.Metadata.CaBundle == nil || json.Metadata.ServerCert == nil || json.Metadata.ServerKey == nil {
+		resp.Diagnostics.AddError(
+			"Kubernetes cluster not staged",
+			fmt.Sprintf("P0 has not yet generated PKI values for the Kubernetes integration %q.", model.Id),
+		)
+		return
+	}
+
+	params := manifestParams{
+		Namespace:                stringOrDefault(model.Namespace, defaultNamespace),
+		ConnectivityType:         "proxy",
+		CaBundle:                 *json.Metadata.CaBundle,
+		ServerCert:               *json.Metadata.ServerCert,
+		ServerKey:                *json.Metadata.ServerKey,
+		AdmissionControllerImage: model.AdmissionControllerImage.ValueString(),
+		BraekhusImage:            model.BraekhusImage.ValueString(),
+		BraekhusStorageClass:     model.BraekhusStorageClass.ValueString(),
+		BraekhusArgs:             model.BraekhusArgs,
+		AdmissionControllerPort:  model.AdmissionControllerPort,
+		AdmissionControllerTls:   model.AdmissionControllerTls,
+		WebhookPath:              model.WebhookPath,
+		WebhookRules:             model.WebhookRules,
+	}
+	if json.Item.Connectivity.ConnectivityType != nil {
+		params.ConnectivityType = *json.Item.Connectivity.ConnectivityType
+	}
+	if params.ConnectivityType == "proxy" && params.BraekhusImage == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("braekhus_image"),
+			"Missing braekhus image",
+			fmt.Sprintf("The Kubernetes integration %q uses 'proxy' connectivity, so 'braekhus_image' is required.", model.Id),
+		)
+		return
+	}
+	if params.ConnectivityType == "proxy" && len(params.BraekhusArgs) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("braekhus_args"),
+			"Missing braekhus arguments",
+			fmt.Sprintf("The Kubernetes integration %q uses 'proxy' connectivity, so 'braekhus_args' is required.", model.Id),
+		)
+		return
+	}
+
+	documents, err := encodeManifests(renderManifests(params))
+	if err != nil {
+		resp.Diagnostics.AddError("Could not render Kubernetes manifests", err.Error())
+		return
+	}
+
+	manifests, diags := types.ListValueFrom(ctx, types.StringType, documents)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	model.Manifests = manifests
+	model.Yaml = types.StringValue(strings.Join(documents, "\n---\n") + "\n")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// encodeManifests serializes each object as JSON. JSON is a subset of YAML, so
+// the same documents are valid input to both YAML- and JSON-based tooling.
+func encodeManifests(objects []map[string]any) ([]string, error) {
+	documents := make([]string, len(objects))
+	for i, object := range objects {
+		encoded, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		documents[i] = string(encoded)
+	}
+	return documents, nil
+}
+
+// base64Pem returns value as base64-encoded PEM. P0 may return the CA bundle
+// either as raw PEM or already encoded; webhook `caBundle` fields require the
+// encoded form.
+func base64Pem(value string) string {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	return value
+}
+
+// restrictedSecurityContext is the security context of every container: a
+// non-root user, without privilege escalation or capabilities, on a read-only
+// root filesystem.
+func restrictedSecurityContext() map[string]any {
+	return map[string]any{
+		"runAsNonRoot":             true,
+		"runAsUser":                nonRootId,
+		"runAsGroup":               nonRootId,
+		"allowPrivilegeEscalation": false,
+		"readOnlyRootFilesystem":   true,
+		"capabilities":             map[string]any{"drop": []any{"ALL"}},
+		"seccompProfile":           map[string]any{"type": "RuntimeDefault"},
+	}
+}
+
+func webhookRules(rules []webhookRule) []any {
+	result := make([]any, len(rules))
+	for i, rule := range rules {
+		result[i] = map[string]any{
+			"apiGroups":   rule.ApiGroups,
+			"apiVersions": rule.ApiVersions,
+			"operations":  rule.Operations,
+			"resources":   rule.Resources,
+		}
+	}
+	return result
+}
+
+func labels(component string) map[string]any {
+	return map[string]any{
+		"app.kubernetes.io/name":       component,
+		"app.kubernetes.io/part-of":    "p0",
+		"app.kubernetes.io/managed-by": "terraform",
+	}
+}
+
+// renderManifests builds the in-cluster components, in the order they must be
+// applied (namespace and identities first, webhook registration last so that
+// its backing service is already running).
+func renderManifests(params manifestParams) []map[string]any {
+	namespace := params.Namespace
+	tlsSecretName := admissionControllerName + "-tls"
+
+	objects := []map[string]any{
+		{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]any{"name": namespace},
+		},
+		{
+			"apiVersion": "v1",
+			"kind":       "ServiceAccount",
+			"metadata":   map[string]any{"name": serviceAccountName, "namespace": namespace},
+		},
+		{
+			// Long-lived token used by P0 to authenticate to the cluster; its
+			// value is the 'token' attribute of p0_kubernetes.
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"type":       "kubernetes.io/service-account-token",
+			"metadata": map[string]any{
+				"name":        serviceAccountSecretName,
+				"namespace":   namespace,
+				"annotations": map[string]any{"kubernetes.io/service-account.name": serviceAccountName},
+			},
+		},
+		{
+			// P0 provisions access by managing RBAC objects, and must be able to
+			// grant any permission it manages.
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "ClusterRoleBinding",
+			"metadata":   map[string]any{"name": serviceAccountName + "-binding"},
+			"roleRef": map[string]any{
+				"apiGroup": "rbac.authorization.k8s.io",
+				"kind":     "ClusterRole",
+				"name":     "cluster-admin",
+			},
+			"subjects": []any{
+				map[string]any{"kind": "ServiceAccount", "name": serviceAccountName, "namespace": namespace},
+			},
+		},
+		{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"type":       "kubernetes.io/tls",
+			"metadata":   map[string]any{"name": tlsSecretName, "namespace": namespace},
+			"stringData": map[string]any{
+				"tls.crt": params.ServerCert,
+				"tls.key": params.ServerKey,
+			},
+		},
+		{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": admissionControllerName, "namespace": namespace, "labels": labels(admissionControllerName)},
+			"spec": map[string]any{
+				"replicas": 1,
+				"selector": map[string]any{"matchLabels": labels(admissionControllerName)},
+				"template": map[string]any{
+					"metadata": map[string]any{"labels": labels(admissionControllerName)},
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{
+								"name":            "admission-controller",
+								"image":           params.AdmissionControllerImage,
+								"ports":           []any{map[string]any{"containerPort": params.AdmissionControllerPort}},
+								"securityContext": restrictedSecurityContext(),
+								"volumeMounts": []any{
+									map[string]any{"name": "tls", "mountPath": params.AdmissionControllerTls, "readOnly": true},
+								},
+							},
+						},
+						"volumes": []any{
+							map[string]any{"name": "tls", "secret": map[string]any{"secretName": tlsSecretName}},
+						},
+					},
+				},
+			},
+		},
+		{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   map[string]any{"name": admissionControllerName, "namespace": namespace},
+			"spec": map[string]any{
+				"selector": labels(admissionControllerName),
+				"ports":    []any{map[string]any{"port": webhookServicePort, "targetPort": params.AdmissionControllerPort}},
+			},
+		},
+	}
+
+	if params.ConnectivityType == "proxy" {
+		keysSpec := map[string]any{
+			"accessModes": []any{"ReadWriteOnce"},
+			"resources":   map[string]any{"requests": map[string]any{"storage": defaultBraekhusVolumeSize}},
+		}
+		if params.BraekhusStorageClass != "" {
+			keysSpec["storageClassName"] = params.BraekhusStorageClass
+		}
+		objects = append(objects,
+			map[string]any{
+				"apiVersion":                   "v1",
+				"kind":                         "ServiceAccount",
+				"metadata":                     map[string]any{"name": braekhusServiceAccountName, "namespace": namespace},
+				"automountServiceAccountToken": false,
+			},
+			map[string]any{
+				// The proxy generates its key pair on first start. The public JWK
+				// is the 'public_jwk' attribute of p0_kubernetes, so the key pair
+				// must survive pod restarts and rescheduling.
+				"apiVersion": "v1",
+				"kind":       "PersistentVolumeClaim",
+				"metadata":   map[string]any{"name": braekhusKeysName, "namespace": namespace},
+				"spec":       keysSpec,
+			},
+			map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]any{"name": braekhusName, "namespace": namespace, "labels": labels(braekhusName)},
+				"spec": map[string]any{
+					"replicas": 1,
+					// The key pair volume can only be attached to one node at a
+					// time.
+					"strategy": map[string]any{"type": "Recreate"},
+					"selector": map[string]any{"matchLabels": labels(braekhusName)},
+					"template": map[string]any{
+						"metadata": map[string]any{"labels": labels(braekhusName)},
+						"spec": map[string]any{
+							"serviceAccountName":           braekhusServiceAccountName,
+							"automountServiceAccountToken": false,
+							// Makes the key pair volume writable by the non-root user.
+							"securityContext": map[string]any{"fsGroup": nonRootId},
+							"containers": []any{
+								map[string]any{
+									"name":            "braekhus",
+									"image":           params.BraekhusImage,
+									"args":            params.BraekhusArgs,
+									"securityContext": restrictedSecurityContext(),
+									"volumeMounts": []any{
+										map[string]any{"name": "p0-files", "mountPath": braekhusFilesPath},
+									},
+								},
+							},
+							"volumes": []any{
+								map[string]any{"name": "p0-files", "persistentVolumeClaim": map[string]any{"claimName": braekhusKeysName}},
+							},
+						},
+					},
+				},
+			},
+		)
+	}
+
+	objects = append(objects, map[string]any{
+		"apiVersion": "admissionregistration.k8s.io/v1",
+		"kind":       "ValidatingWebhookConfiguration",
+		"metadata":   map[string]any{"name": admissionControllerName},
+		"webhooks": []any{
+			map[string]any{
+				"name":                    admissionControllerName + ".p0.dev",
+				"admissionReviewVersions": []any{"v1"},
+				"sideEffects":             "None",
+				"failurePolicy":           "Ignore",
+				"clientConfig": map[string]any{
+					"caBundle": base64Pem(params.CaBundle),
+					"service": map[string]any{
+						"name":      admissionControllerName,
+						"namespace": namespace,
+						"path":      params.WebhookPath,
+						"port":      webhookServicePort,
+					},
+				},
+				"rules": webhookRules(params.WebhookRules),
+			},
+		},
+	})
+
+	return objects
+}
@@ -35,6 +35,22 @@ var unitLabels = map[string]string{
 // them.
 var durationUnits = []string{"s", "m", "h", "d", "w"}
 
+// unitSeconds is the length of each duration unit, in seconds.
+var unitSeconds = map[string]int64{
+	"s": 1,
+	"m": 60,
+	"h": 60 * 60,
+	"d": 24 * 60 * 60,
+	"w": 7 * 24 * 60 * 60,
+}
+
+// DurationSeconds returns the length of a duration in seconds, or false if unit
+// is not one of the accepted units.
+func DurationSeconds(time int64, unit string) (int64, bool) {
+	seconds, ok := unitSeconds[unit]
+	return time * seconds, ok
+}
+
 // computeValue reproduces the P0 backend's derived duration label
 // (convertDurationToDurationOption in shared/src/permission-requests/util.ts):
 // "<time> <unit-label>", pluralized when time != 1. This is the key P0 uses to
@@ -47,8 +63,8 @@ func computeValue(time int64, unit string) string {
 	return fmt.Sprintf("%d %s", time, label)
 }
 
-// durationAttributes are the attributes shared by every duration object.
-func durationAttributes() map[string]schema.Attribute {
+// DurationAttributes are the attributes shared by every duration object.
+func DurationAttributes() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"time": schema.Int64Attribute{
 			MarkdownDescription: "The number of `unit`s in this duration. Must be a positive integer.",
@@ -73,6 +89,6 @@ func durationAttribute(markdownDescription string) schema.SingleNestedAttribute
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: markdownDescription,
 		Required:            true,
-		Attributes:          durationAttributes(),
+		Attributes:          DurationAttributes(),
 	}
 }
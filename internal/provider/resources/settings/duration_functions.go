@@ -0,0 +1,174 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package settings
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &DurationFunction{}
+var _ function.Function = &DurationLabelFunction{}
+
+// durationAttrTypes is the object type of a durationOption, as accepted and
+// returned by the duration functions.
+var durationAttrTypes = map[string]attr.Type{
+	"time": types.Int64Type,
+	"unit": types.StringType,
+}
+
+// durationComponent matches one "<count><unit>" component of a duration string.
+var durationComponent = regexp.MustCompile(`(\d+)([smhdw])`)
+
+// parseDuration converts a duration string into the equivalent durationOption,
+// expressed in the largest unit that represents it exactly. A duration string
+// is one or more "<count><unit>" components (e.g. "36h" or "1d12h"), where unit
+// is one of durationUnits.
+func parseDuration(s string) (durationOption, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return durationOption{}, fmt.Errorf("duration must not be empty")
+	}
+	matches := durationComponent.FindAllStringSubmatchIndex(trimmed, -1)
+	var seconds int64
+	end := 0
+	for _, m := range matches {
+		if m[0] != end {
+			break
+		}
+		count, err := strconv.ParseInt(trimmed[m[2]:m[3]], 10, 64)
+		unit := unitSeconds[trimmed[m[4]:m[5]]]
+		if err != nil || count > (math.MaxInt64-seconds)/unit {
+			return durationOption{}, fmt.Errorf("duration %q is too large", s)
+		}
+		seconds += count * unit
+		end = m[1]
+	}
+	if end != len(trimmed) {
+		return durationOption{}, fmt.Errorf("invalid duration %q: expected one or more <count><unit> components (e.g. \"36h\" or \"1d12h\"), where unit is one of %s", s, strings.Join(durationUnits, ", "))
+	}
+	if seconds <= 0 {
+		return durationOption{}, fmt.Errorf("duration %q must be positive", s)
+	}
+
+	// durationUnits is ordered smallest to largest; pick the largest exact one.
+	for i := len(durationUnits) - 1; i >= 0; i-- {
+		unit := durationUnits[i]
+		if seconds%unitSeconds[unit] == 0 {
+			return durationOption{Time: seconds / unitSeconds[unit], Unit: unit}, nil
+		}
+	}
+	// Unreachable: every whole number of seconds is an exact count of "s".
+	return durationOption{Time: seconds, Unit: "s"}, nil
+}
+
+// validateDuration reports whether o is a duration P0 accepts, mirroring the
+// validators in DurationAttributes.
+func validateDuration(o durationOption) error {
+	if o.Time < 1 {
+		return fmt.Errorf("time must be a positive integer, got %d", o.Time)
+	}
+	if !slices.Contains(durationUnits, o.Unit) {
+		return fmt.Errorf("unit must be one of %s, got %q", strings.Join(durationUnits, ", "), o.Unit)
+	}
+	return nil
+}
+
+func NewDurationFunction() function.Function {
+	return &DurationFunction{}
+}
+
+// DurationFunction parses a duration string into a duration object.
+type DurationFunction struct{}
+
+func (f *DurationFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "duration"
+}
+
+func (f *DurationFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a duration string into a P0 duration object",
+		MarkdownDescription: `Converts a duration string, such as ` + "`\"36h\"`" + ` or ` + "`\"1d12h\"`" + `, into the ` + "`{ time, unit }`" + ` object
+accepted by P0 duration settings (e.g. ` + "`p0_access_durations`" + ` and ` + "`p0_expiry_options`" + `).
+
+The duration is expressed in the largest unit that represents it exactly: ` + "`\"48h\"`" + ` becomes
+` + "`{ time = 2, unit = \"d\" }`" + `, while ` + "`\"36h\"`" + ` remains ` + "`{ time = 36, unit = \"h\" }`" + `.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "duration",
+				MarkdownDescription: "One or more `<count><unit>` components, where unit is one of `s` (seconds), `m` (minutes), `h` (hours), `d` (days), or `w` (weeks).",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: durationAttrTypes,
+		},
+	}
+}
+
+func (f *DurationFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	option, err := parseDuration(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, option))
+}
+
+func NewDurationLabelFunction() function.Function {
+	return &DurationLabelFunction{}
+}
+
+// DurationLabelFunction computes the label P0 derives for a duration object.
+type DurationLabelFunction struct{}
+
+func (f *DurationLabelFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "duration_label"
+}
+
+func (f *DurationLabelFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the label P0 displays for a duration object",
+		MarkdownDescription: `Returns the label P0 derives for a ` + "`{ time, unit }`" + ` duration object, e.g. ` + "`\"36 hours\"`" + ` or
+` + "`\"1 week\"`" + `. This is the label requestors see for an expiry option.`,
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "duration",
+				MarkdownDescription: "A duration object, e.g. the result of `provider::p0::duration`.",
+				AttributeTypes:      durationAttrTypes,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DurationLabelFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input durationOption
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateDuration(input); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, computeValue(input.Time, input.Unit)))
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package settings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    durationOption
+		wantErr bool
+	}{
+		{input: "36h", want: durationOption{Time: 36, Unit: "h"}},
+		{input: "48h", want: durationOption{Time: 2, Unit: "d"}},
+		{input: "14d", want: durationOption{Time: 2, Unit: "w"}},
+		{input: "90m", want: durationOption{Time: 90, Unit: "m"}},
+		{input: "120s", want: durationOption{Time: 2, Unit: "m"}},
+		{input: "1d12h", want: durationOption{Time: 36, Unit: "h"}},
+		{input: "1w", want: durationOption{Time: 1, Unit: "w"}},
+		{input: " 2h ", want: durationOption{Time: 2, Unit: "h"}},
+		{input: "", wantErr: true},
+		{input: "0h", wantErr: true},
+		{input: "36", wantErr: true},
+		{input: "h", wantErr: true},
+		{input: "1y", wantErr: true},
+		{input: "1.5h", wantErr: true},
+		{input: "-1h", wantErr: true},
+		{input: "1h 30m", wantErr: true},
+		{input: "99999999999999999999s", wantErr: true},
+		{input: "9223372036854775807w", wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := parseDuration(c.input)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseDuration(%q) = %+v; want error", c.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDuration(%q) returned error: %s", c.input, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("parseDuration(%q) = %+v; want %+v", c.input, got, c.want)
+		}
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	cases := []struct {
+		option  durationOption
+		wantErr bool
+	}{
+		{durationOption{Time: 1, Unit: "w"}, false},
+		{durationOption{Time: 0, Unit: "h"}, true},
+		{durationOption{Time: 1, Unit: "y"}, true},
+	}
+	for _, c := range cases {
+		err := validateDuration(c.option)
+		if (err != nil) != c.wantErr {
+			t.Errorf("validateDuration(%+v) error = %v; want error: %t", c.option, err, c.wantErr)
+		}
+	}
+}
+
+// TestDurationFunctionsRun verifies that the functions' results round-trip
+// through the framework's object type.
+func TestDurationFunctionsRun(t *testing.T) {
+	ctx := context.Background()
+
+	durationResp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(durationAttrTypes))}
+	NewDurationFunction().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("48h")}),
+	}, &durationResp)
+	if durationResp.Error != nil {
+		t.Fatalf("duration returned error: %s", durationResp.Error)
+	}
+	object, ok := durationResp.Result.Value().(types.Object)
+	if !ok {
+		t.Fatalf("duration returned %T; want types.Object", durationResp.Result.Value())
+	}
+
+	labelResp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewDurationLabelFunction().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{object}),
+	}, &labelResp)
+	if labelResp.Error != nil {
+		t.Fatalf("duration_label returned error: %s", labelResp.Error)
+	}
+	if got := labelResp.Result.Value().(types.String).ValueString(); got != "2 days" {
+		t.Errorf("duration_label(duration(\"48h\")) = %q; want \"2 days\"", got)
+	}
+
+	errResp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(durationAttrTypes))}
+	NewDurationFunction().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("36")}),
+	}, &errResp)
+	if errResp.Error == nil || errResp.Error.FunctionArgument == nil || *errResp.Error.FunctionArgument != 0 {
+		t.Errorf("duration(\"36\") error = %v; want an argument error", errResp.Error)
+	}
+}
@@ -50,7 +50,7 @@ Options are de-duplicated by their ` + "`time`" + ` and ` + "`unit`" + `. The P0
 				MarkdownDescription: "The list of selectable request durations.",
 				Required:            true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: durationAttributes(),
+					Attributes: DurationAttributes(),
 				},
 			},
 		},
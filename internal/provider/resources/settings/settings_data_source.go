@@ -0,0 +1,147 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+var _ datasource.DataSource = &Settings{}
+var _ datasource.DataSourceWithConfigure = &Settings{}
+
+// Settings reads an organization's effective access durations and expiry
+// options. Unlike p0_access_durations and p0_expiry_options, which only track
+// what Terraform last applied, this reflects the values P0 currently enforces.
+type Settings struct {
+	data *internal.P0ProviderData
+}
+
+func NewSettings() datasource.DataSource {
+	return &Settings{}
+}
+
+type settingsModel struct {
+	Approvable     *durationOption  `tfsdk:"approvable"`
+	MaxAccess      *durationOption  `tfsdk:"max_access"`
+	StandingAccess *durationOption  `tfsdk:"standing_access"`
+	ExpiryOptions  []durationOption `tfsdk:"expiry_options"`
+}
+
+// settingsApi is the subset of the organization's settings document read here.
+// Durations that have never been set are absent. Expiry options also carry the
+// derived `value` label (see computeValue), which is dropped on decode.
+type settingsApi struct {
+	ApprovableDuration     *durationOption  `json:"approvableDuration"`
+	MaxAccessDuration      *durationOption  `json:"maxAccessDuration"`
+	StandingAccessDuration *durationOption  `json:"standingAccessDuration"`
+	ExpiryOptions          []durationOption `json:"expiryOptions"`
+}
+
+// errSettingsUnavailable reports that the P0 API has no settings read endpoint
+// for this organization.
+var errSettingsUnavailable = errors.New("the P0 API did not find the settings endpoint (GET settings); this data source requires a P0 API version that serves it")
+
+// readSettings fetches the organization's settings document. A 404 means the
+// endpoint itself is unavailable, since every organization has settings.
+func readSettings(data *internal.P0ProviderData) (*settingsApi, error) {
+	var json settingsApi
+	httpResp, err := data.Get("settings", &json)
+	if httpResp != nil && httpResp.StatusCode == 404 {
+		return nil, errSettingsUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &json, nil
+}
+
+// sortedOptions returns the expiry options ordered from shortest to longest, as
+// P0 presents them to requestors. Equal-length options keep their API order.
+func sortedOptions(options []durationOption) []durationOption {
+	sorted := append([]durationOption{}, options...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time*unitSeconds[sorted[i].Unit] < sorted[j].Time*unitSeconds[sorted[j].Unit]
+	})
+	return sorted
+}
+
+// durationDataAttribute is a computed duration object.
+func durationDataAttribute(markdownDescription string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: markdownDescription,
+		Computed:            true,
+		Attributes:          durationDataAttributes(),
+	}
+}
+
+// durationDataAttributes are the computed counterparts of DurationAttributes.
+func durationDataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"time": schema.Int64Attribute{
+			MarkdownDescription: "The number of `unit`s in this duration.",
+			Computed:            true,
+		},
+		"unit": schema.StringAttribute{
+			MarkdownDescription: "The duration unit. One of `s` (seconds), `m` (minutes), `h` (hours), `d` (days), or `w` (weeks).",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *Settings) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings"
+}
+
+func (d *Settings) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `An organization's effective access durations and expiry options.
+
+Use this data source to reference or assert on these settings from a configuration that does not own the
+` + "`p0_access_durations`" + ` and ` + "`p0_expiry_options`" + ` singletons. Unlike those resources, it reads the values
+P0 currently enforces, including changes made outside of Terraform.`,
+		Attributes: map[string]schema.Attribute{
+			"approvable":      durationDataAttribute("The maximum amount of time between when a request is made and when it can be approved. Null if never set."),
+			"max_access":      durationDataAttribute("The maximum duration for which access may be granted. Null if never set."),
+			"standing_access": durationDataAttribute("The maximum duration of standing (persistent) access before it must be re-approved. Null if never set."),
+			"expiry_options": schema.ListNestedAttribute{
+				MarkdownDescription: "The selectable request durations, ordered from shortest to longest.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: durationDataAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *Settings) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := internal.ConfigureDataSource(&req, resp)
+	if data != nil {
+		d.data = data
+	}
+}
+
+func (d *Settings) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	json, err := readSettings(d.data)
+	if err != nil {
+		resp.Diagnostics.AddError("Error communicating with P0", fmt.Sprintf("Unable to read settings:\n%s", err))
+		return
+	}
+
+	model := settingsModel{
+		Approvable:     json.ApprovableDuration,
+		MaxAccess:      json.MaxAccessDuration,
+		StandingAccess: json.StandingAccessDuration,
+		ExpiryOptions:  sortedOptions(json.ExpiryOptions),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
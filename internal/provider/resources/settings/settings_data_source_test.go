@@ -0,0 +1,77 @@
+// Copyright (c) 2025 P0 Security, Inc
+// SPDX-License-Identifier: MPL-2.0
+
+package settings
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/p0-security/terraform-provider-p0/internal"
+)
+
+// TestSortedOptions verifies that expiry options are ordered by their length,
+// not by their (time, unit) representation.
+func TestSortedOptions(t *testing.T) {
+	options := []durationOption{
+		{Time: 1, Unit: "w"},
+		{Time: 90, Unit: "m"},
+		{Time: 1, Unit: "d"},
+		{Time: 1, Unit: "h"},
+		{Time: 24, Unit: "h"},
+	}
+	want := []durationOption{
+		{Time: 1, Unit: "h"},
+		{Time: 90, Unit: "m"},
+		{Time: 1, Unit: "d"},
+		{Time: 24, Unit: "h"},
+		{Time: 1, Unit: "w"},
+	}
+
+	got := sortedOptions(options)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedOptions() = %+v; want %+v", got, want)
+	}
+	if options[0] != (durationOption{Time: 1, Unit: "w"}) {
+		t.Error("sortedOptions() modified its input")
+	}
+	if got := sortedOptions(nil); got == nil || len(got) != 0 {
+		t.Errorf("sortedOptions(nil) = %#v; want an empty list", got)
+	}
+}
+
+// TestReadSettings verifies that the settings document is decoded, and that a
+// missing settings endpoint is reported as such.
+func TestReadSettings(t *testing.T) {
+	found := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !found || r.Method != http.MethodGet || r.URL.Path != "/settings" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"maxAccessDuration": {"time": 1, "unit": "d"}, "expiryOptions": [{"time": 1, "unit": "h", "value": "1h"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	data := &internal.P0ProviderData{BaseUrl: server.URL, Authentication: "Bearer x", Client: server.Client()}
+
+	json, err := readSettings(data)
+	if err != nil {
+		t.Fatalf("readSettings: %v", err)
+	}
+	want := settingsApi{
+		MaxAccessDuration: &durationOption{Time: 1, Unit: "d"},
+		ExpiryOptions:     []durationOption{{Time: 1, Unit: "h"}},
+	}
+	if !reflect.DeepEqual(*json, want) {
+		t.Errorf("readSettings() = %+v; want %+v", *json, want)
+	}
+
+	found = false
+	if _, err := readSettings(data); !errors.Is(err, errSettingsUnavailable) {
+		t.Errorf("readSettings() error = %v; want errSettingsUnavailable", err)
+	}
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
@@ -23,3 +24,22 @@ func Configure(req *resource.ConfigureRequest, resp *resource.ConfigureResponse)
 	}
 	return &data
 }
+
+// ConfigureDataSource is the data-source counterpart of Configure.
+func ConfigureDataSource(req *datasource.ConfigureRequest, resp *datasource.ConfigureResponse) *P0ProviderData {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	data, ok := req.ProviderData.(P0ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected P0ProviderData, got: %T. Please report this issue to support@p0.dev.", req.ProviderData),
+		)
+
+		return nil
+	}
+	return &data
+}
@@ -11,6 +11,7 @@ import (
 	"math/rand"
 	"net/http"
 	"reflect"
+	"sync"
 	"time"
 )
 
@@ -24,6 +25,42 @@ type P0ProviderData struct {
 	Authentication string
 	UserAgent      string
 	Client         *http.Client
+	// Org, Host, and AuthSource describe how the provider was configured; they
+	// are not used to make requests, only exposed via the p0_current data source.
+	Org        string
+	Host       string
+	AuthSource string
+	// Cache is shared by every copy of the provider data; see GetCached.
+	Cache *ResponseCache
+}
+
+// ResponseCache holds the responses of GetCached requests for the lifetime of
+// a provider instance. Terraform starts a new instance for each plan or apply.
+type ResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+type cachedResponse struct {
+	once sync.Once
+	resp *http.Response
+	body json.RawMessage
+	err  error
+}
+
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{entries: map[string]*cachedResponse{}}
+}
+
+func (c *ResponseCache) entry(path string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[path]
+	if !ok {
+		entry = &cachedResponse{}
+		c.entries[path] = entry
+	}
+	return entry
 }
 
 const (
@@ -150,6 +187,24 @@ func (data *P0ProviderData) Get(path string, responseJson any) (*http.Response,
 	return data.Do(req, responseJson)
 }
 
+// GetCached is Get, except that the response (or error) of the first request
+// for path is reused for the rest of the provider instance's lifetime, so it
+// does not reflect changes made since. Use it only for advisory reads, such as
+// plan-time analysis that many resources repeat. Without a Cache, it is Get.
+func (data *P0ProviderData) GetCached(path string, responseJson any) (*http.Response, error) {
+	if data.Cache == nil {
+		return data.Get(path, responseJson)
+	}
+	entry := data.Cache.entry(path)
+	entry.once.Do(func() {
+		entry.resp, entry.err = data.Get(path, &entry.body)
+	})
+	if entry.err != nil || len(entry.body) == 0 {
+		return entry.resp, entry.err
+	}
+	return entry.resp, json.Unmarshal(entry.body, responseJson)
+}
+
 func (data *P0ProviderData) Delete(path string) (*http.Response, error) {
 	req, errNew := http.NewRequest("DELETE", fmt.Sprintf("%s/%s", data.BaseUrl, path), nil)
 	if errNew != nil {
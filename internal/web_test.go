@@ -159,3 +159,40 @@ func TestDoBodyMarshalsRequest(t *testing.T) {
 		t.Errorf("expected application/json Content-Type, got %q", gotContentType)
 	}
 }
+
+// TestGetCached verifies that GetCached requests each path once per cache, and
+// that every caller gets its own decoded copy of the response.
+func TestGetCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"names": ["eng", "ops"]}`))
+	}))
+	defer server.Close()
+
+	data := P0ProviderData{BaseUrl: server.URL, Authentication: "Bearer x", Client: server.Client(), Cache: NewResponseCache()}
+	copied := data
+	for _, d := range []*P0ProviderData{&data, &copied} {
+		var response struct {
+			Names []string `json:"names"`
+		}
+		if _, err := d.GetCached("policy", &response); err != nil {
+			t.Fatalf("GetCached returned error: %v", err)
+		}
+		if len(response.Names) != 2 || response.Names[0] != "eng" {
+			t.Errorf("GetCached decoded %+v; want [eng ops]", response)
+		}
+		response.Names[0] = "modified"
+	}
+	if requests != 1 {
+		t.Errorf("GetCached sent %d requests; want 1 shared by copies of the provider data", requests)
+	}
+
+	var response map[string]any
+	if _, err := data.Get("policy", &response); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Get sent %d requests in total; want it to bypass the cache", requests)
+	}
+}